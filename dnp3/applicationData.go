@@ -1,6 +1,7 @@
 package dnp3
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -33,17 +34,47 @@ func NewApplicationDataFromBytes(data []byte) (*ApplicationData, error) {
 }
 
 func (ad *ApplicationData) DecodeFromBytes(data []byte) error {
+	return ad.decodeWithOptions(data, strictDecoding)
+}
+
+// decodeWithOptions is DecodeFromBytes with opts. Without
+// opts.Strict, decoding stops at the first object that can't be decoded,
+// keeping the objects before it and the rest of data in the extra bytes.
+func (ad *ApplicationData) decodeWithOptions(data []byte, opts DecodeOptions) error {
 	ad.Objects = nil // in case there was already stuff here
 
 	for readOffset := 0; readOffset < len(data); {
 		var object DataObject
 
-		err := object.DecodeFromBytes(data[readOffset:])
+		err := object.decodeWithOptions(data[readOffset:], opts)
+		if err != nil {
+			ad.extra = data[readOffset:]
+
+			return opts.tolerate("application", ad.extra, fmt.Errorf("could not decode object: 0x % X, err: %w",
+				data[readOffset:], err))
+		}
+
+		ad.Objects = append(ad.Objects, object)
+		readOffset += object.SizeOf()
+	}
+
+	return nil
+}
+
+// decodeHeadersFromBytes is DecodeFromBytes for requests whose objects are
+// bare headers, such as Read.
+func (ad *ApplicationData) decodeHeadersFromBytes(data []byte, opts DecodeOptions) error {
+	ad.Objects = nil
+
+	for readOffset := 0; readOffset < len(data); {
+		var object DataObject
+
+		err := object.decodeHeaderFromBytes(data[readOffset:], opts)
 		if err != nil {
 			ad.extra = data[readOffset:]
 
-			return fmt.Errorf("could not decode object: 0x % X, err: %w",
-				data[readOffset:], err)
+			return opts.tolerate("application", ad.extra, fmt.Errorf("could not decode object header: 0x % X, err: %w",
+				data[readOffset:], err))
 		}
 
 		ad.Objects = append(ad.Objects, object)
@@ -110,6 +141,40 @@ func (ad *ApplicationData) SetExtra(extra []byte) {
 	ad.extra = extra
 }
 
+// MarshalJSON encodes the objects along with any undecoded extra bytes.
+func (ad *ApplicationData) MarshalJSON() ([]byte, error) {
+	type applicationDataAlias ApplicationData
+
+	jsonBytes, err := json.Marshal(struct {
+		*applicationDataAlias
+
+		Extra []byte `json:"extra,omitempty"`
+	}{(*applicationDataAlias)(ad), ad.extra})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal application data: %w", err)
+	}
+
+	return jsonBytes, nil
+}
+
+// UnmarshalJSON is the inverse of MarshalJSON.
+func (ad *ApplicationData) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Objects []DataObject `json:"objects"`
+		Extra   []byte       `json:"extra"`
+	}
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal application data: %w", err)
+	}
+
+	ad.Objects = raw.Objects
+	ad.extra = raw.Extra
+
+	return nil
+}
+
 type DataObject struct {
 	Header    ObjectHeader `json:"header"`
 	Points    []Point      `json:"points"`
@@ -137,7 +202,13 @@ func NewDataObjectFromBytes(data []byte) (*DataObject, error) {
 }
 
 func (do *DataObject) DecodeFromBytes(data []byte) error {
-	err := do.Header.DecodeFromBytes(data)
+	return do.decodeWithOptions(data, strictDecoding)
+}
+
+// decodeWithOptions is DecodeFromBytes with opts, which apply to
+// the header (see ObjectHeader.decodeWithOptions).
+func (do *DataObject) decodeWithOptions(data []byte, opts DecodeOptions) error {
+	err := do.Header.decodeWithOptions(data, opts)
 	if err != nil {
 		return fmt.Errorf("can't create Data Object Header: %w", err)
 	}
@@ -180,6 +251,55 @@ func (do *DataObject) DecodeFromBytes(data []byte) error {
 	return nil
 }
 
+// decodeHeaderFromBytes decodes an object that has no point values. Index
+// prefixes, when the qualifier has them, follow the header; they are kept in
+// Extra so the object re-encodes unchanged, and are available from Indexes.
+// Objects with a size prefix are decoded in full.
+func (do *DataObject) decodeHeaderFromBytes(data []byte, opts DecodeOptions) error {
+	err := do.Header.decodeWithOptions(data, opts)
+	if err != nil {
+		return fmt.Errorf("can't create Data Object Header: %w", err)
+	}
+
+	do.totalSize = do.Header.SizeOf()
+
+	switch do.Header.PointPrefixCode {
+	case NoPrefix:
+		return nil
+	case Index1Octet, Index2Octet, Index4Octet:
+	case Size1Octet, Size2Octet, Size4Octet:
+		// Size-prefixed objects always carry their values, like the file
+		// transport object that names the block a Read asks for.
+		return do.decodeWithOptions(data, opts)
+	case Reserved:
+		return fmt.Errorf("point prefix code %s can't be used without point values",
+			do.Header.PointPrefixCode)
+	default:
+		return fmt.Errorf("unexpected point prefix code %d", do.Header.PointPrefixCode)
+	}
+
+	prefSize := do.Header.PointPrefixCode.GetPointPrefixSize()
+	size := do.Header.RangeField.NumObjects() * prefSize
+
+	if len(data) < do.totalSize+size {
+		return fmt.Errorf("not enough bytes for %d index prefixes", do.Header.RangeField.NumObjects())
+	}
+
+	do.Extra = data[do.totalSize : do.totalSize+size]
+	do.totalSize += size
+
+	for offset := 0; offset < size; offset += prefSize {
+		index, err := prefixToInt(do.Extra[offset : offset+prefSize])
+		if err != nil {
+			return fmt.Errorf("could not decode index prefix: %w", err)
+		}
+
+		do.indexes = append(do.indexes, index)
+	}
+
+	return nil
+}
+
 func (do *DataObject) SerializeTo() ([]byte, error) {
 	// TODO get this to be more elegant.
 	var encoded []byte
@@ -251,6 +371,54 @@ func (do *DataObject) String() string {
 	return output
 }
 
+// UnmarshalJSON rebuilds Points with the concrete Point type the header's
+// group/variation and point prefix code would decode to.
+func (do *DataObject) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Header ObjectHeader      `json:"header"`
+		Points []json.RawMessage `json:"points"`
+		Extra  []byte            `json:"extra"`
+	}
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal data object: %w", err)
+	}
+
+	*do = DataObject{Header: raw.Header, Extra: raw.Extra}
+
+	if len(raw.Points) == 0 {
+		return nil
+	}
+
+	if do.Header.objectType == nil {
+		return fmt.Errorf("unsupported group/variation: %d/%d",
+			do.Header.Group, do.Header.Variation)
+	}
+
+	points, err := do.Header.objectType.blankPoints(len(raw.Points), do.Header.PointPrefixCode)
+	if err != nil {
+		return fmt.Errorf("can't create points for %d/%d: %w",
+			do.Header.Group, do.Header.Variation, err)
+	}
+
+	for i, point := range points {
+		err = json.Unmarshal(raw.Points[i], point)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal point %d: %w", i, err)
+		}
+	}
+
+	do.Points = points
+
+	err = do.updateIndexes()
+	if err != nil {
+		return fmt.Errorf("failed to update indexes: %w", err)
+	}
+
+	return nil
+}
+
 func (do *DataObject) SizeOf() int {
 	return do.totalSize
 }
@@ -302,10 +470,9 @@ func (do *DataObject) updateIndexesFromPrefix() error {
 
 		return nil
 	case Size1Octet, Size2Octet, Size4Octet:
-		return fmt.Errorf(
-			"point prefix code %s does not determine indexes",
-			do.Header.PointPrefixCode,
-		)
+		// Objects with a size prefix, such as the file transfer objects,
+		// aren't indexed.
+		return nil
 	case Reserved:
 		return errors.New("reserved point prefix code cannot be used to determine indexes")
 	default:
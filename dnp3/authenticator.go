@@ -0,0 +1,523 @@
+package dnp3
+
+import (
+	"crypto/aes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+)
+
+// DefaultChallengeDataSize is used when an authenticator's ChallengeDataSize
+// is zero. It is the smallest challenge secure authentication allows.
+const DefaultChallengeDataSize = 4
+
+var (
+	// ErrAuthentication is returned when a MAC, a wrapped key or a sequence
+	// number doesn't check out.
+	ErrAuthentication = errors.New("secure authentication failed")
+	// ErrNoSessionKeys is returned when a challenge or reply needs session
+	// keys that haven't been set yet.
+	ErrNoSessionKeys = errors.New("session keys are not set")
+	// ErrMACAlgorithm is returned for a MAC algorithm other than HMAC-SHA256.
+	ErrMACAlgorithm = errors.New("unsupported MAC algorithm")
+	// ErrKeyWrapAlgorithm is returned for an unknown key wrap algorithm, or an
+	// update key of the wrong length for it.
+	ErrKeyWrapAlgorithm = errors.New("unsupported key wrap algorithm")
+)
+
+// keyWrapIV is the default initial value of RFC 3394 AES key wrap.
+var keyWrapIV = []byte{0xA6, 0xA6, 0xA6, 0xA6, 0xA6, 0xA6, 0xA6, 0xA6}
+
+// SessionKeys are the session keys of a user. The control direction key
+// authenticates the master's critical requests, the monitor direction key
+// proves a session key change to the master.
+type SessionKeys struct {
+	Control []byte `json:"control"`
+	Monitor []byte `json:"monitor"`
+}
+
+// MasterAuthenticator is the master side of secure authentication (Group
+// 120) for one user: it sets the session keys from the update key it shares
+// with the outstation, and answers the outstation's challenges. It only
+// builds and checks objects; sending them is up to the caller.
+type MasterAuthenticator struct {
+	User      uint16
+	UpdateKey []byte
+	KeyWrap   KeyWrapAlgorithm
+	// Rand is the source of the session keys. Nil uses crypto/rand.
+	Rand io.Reader
+
+	mu      sync.Mutex
+	keys    *SessionKeys
+	pending *SessionKeys
+	change  []byte
+}
+
+// KeyStatusRequest returns the request that starts a session key change.
+func (m *MasterAuthenticator) KeyStatusRequest() *AuthKeyStatusRequest {
+	return &AuthKeyStatusRequest{User: m.User}
+}
+
+// ChangeKeys answers the outstation's session key status with new session
+// keys, wrapped with the update key together with that status. The keys are
+// only used once ConfirmKeys accepts the status that answers the change.
+func (m *MasterAuthenticator) ChangeKeys(status *AuthKeyStatus) (*AuthKeyChange, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if status.User != m.User {
+		return nil, fmt.Errorf("%w: key status for user %d, not %d", ErrAuthentication, status.User, m.User)
+	}
+
+	if status.KeyWrap != m.KeyWrap {
+		return nil, fmt.Errorf("%w: outstation asked for %s, not %s", ErrKeyWrapAlgorithm, status.KeyWrap, m.KeyWrap)
+	}
+
+	size, err := keyWrapSize(m.KeyWrap, m.UpdateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := &SessionKeys{Control: make([]byte, size), Monitor: make([]byte, size)}
+
+	_, err = io.ReadFull(authRand(m.Rand), keys.Control)
+	if err == nil {
+		_, err = io.ReadFull(authRand(m.Rand), keys.Monitor)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("generating session keys: %w", err)
+	}
+
+	wrapped, err := wrapKey(m.UpdateKey, keyWrapData(keys, status.encodeBody()))
+	if err != nil {
+		return nil, err
+	}
+
+	change := &AuthKeyChange{KeySequence: status.KeySequence, User: m.User, WrappedKeys: wrapped}
+	m.pending = keys
+	m.change = change.encodeBody()
+
+	return change, nil
+}
+
+// ConfirmKeys checks the session key status that answers ChangeKeys, whose
+// MAC over the key change must match the new monitor direction key, and
+// starts using the new keys.
+func (m *MasterAuthenticator) ConfirmKeys(status *AuthKeyStatus) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.pending == nil {
+		return fmt.Errorf("%w: no session key change to confirm", ErrAuthentication)
+	}
+
+	if status.Status != KeyStatusOK {
+		return fmt.Errorf("%w: session key status %s", ErrAuthentication, status.Status)
+	}
+
+	mac, err := authMAC(status.Algorithm, m.pending.Monitor, m.change)
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal(mac, status.MAC) {
+		return fmt.Errorf("%w: session key status MAC does not match", ErrAuthentication)
+	}
+
+	m.keys, m.pending, m.change = m.pending, nil, nil
+
+	return nil
+}
+
+// Keys returns the session keys in use, or nil before ConfirmKeys.
+func (m *MasterAuthenticator) Keys() *SessionKeys {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.keys
+}
+
+// Reply answers a challenge of the critical request asdu (the application
+// layer fragment the master sent) with the MAC of both under the control
+// direction key.
+func (m *MasterAuthenticator) Reply(challenge *AuthChallenge, asdu []byte) (*AuthReply, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.keys == nil {
+		return nil, ErrNoSessionKeys
+	}
+
+	mac, err := authMAC(challenge.Algorithm, m.keys.Control, challenge.encodeBody(), asdu)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthReply{Sequence: challenge.Sequence, User: challenge.User, MAC: mac}, nil
+}
+
+// OutstationAuthenticator is the outstation side of secure authentication
+// (Group 120) for one user: it reports the session key status, accepts
+// session key changes wrapped with the update key it shares with the
+// master, and challenges critical requests. It only builds and checks
+// objects; sending them is up to the caller.
+type OutstationAuthenticator struct {
+	User      uint16
+	UpdateKey []byte
+	KeyWrap   KeyWrapAlgorithm
+	Algorithm MACAlgorithm
+	// ChallengeDataSize is the length of the challenge data. Zero uses
+	// DefaultChallengeDataSize.
+	ChallengeDataSize int
+	// Rand is the source of the challenge data. Nil uses crypto/rand.
+	Rand io.Reader
+
+	mu                sync.Mutex
+	keys              *SessionKeys
+	status            KeyStatus
+	keySequence       uint32
+	lastStatus        []byte
+	challengeSequence uint32
+	challenge         []byte
+	asdu              []byte
+}
+
+// KeyStatus answers a session key status request with the current status
+// and fresh challenge data, which the next session key change must wrap.
+func (o *OutstationAuthenticator) KeyStatus() (*AuthKeyStatus, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.nextStatus(nil)
+}
+
+// ChangeKeys unwraps the session keys of a session key change and answers
+// with the session key status. When the change is accepted the status is OK
+// and carries the MAC of the change under the new monitor direction key.
+// Otherwise the old keys are dropped, and both an AuthFail status and an
+// error wrapping ErrAuthentication are returned.
+func (o *OutstationAuthenticator) ChangeKeys(change *AuthKeyChange) (*AuthKeyStatus, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	keys, err := o.unwrapChange(change)
+	if err != nil {
+		o.keys = nil
+		o.status = KeyStatusAuthFail
+
+		status, statusErr := o.nextStatus(nil)
+		if statusErr != nil {
+			return nil, statusErr
+		}
+
+		return status, err
+	}
+
+	mac, err := authMAC(o.Algorithm, keys.Monitor, change.encodeBody())
+	if err != nil {
+		return nil, err
+	}
+
+	o.keys = keys
+	o.status = KeyStatusOK
+
+	return o.nextStatus(mac)
+}
+
+// Challenge challenges the critical request asdu (the application layer
+// fragment the master sent). The master's AuthReply goes to VerifyReply.
+func (o *OutstationAuthenticator) Challenge(asdu []byte) (*AuthChallenge, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.keys == nil {
+		return nil, ErrNoSessionKeys
+	}
+
+	data, err := o.challengeData()
+	if err != nil {
+		return nil, err
+	}
+
+	o.challengeSequence++
+
+	challenge := &AuthChallenge{
+		Sequence:  o.challengeSequence,
+		User:      o.User,
+		Algorithm: o.Algorithm,
+		Reason:    ChallengeReasonCritical,
+		Data:      data,
+	}
+	o.challenge = challenge.encodeBody()
+	o.asdu = slices.Clone(asdu)
+
+	return challenge, nil
+}
+
+// VerifyReply checks the reply to the last challenge. Each challenge can be
+// answered once.
+func (o *OutstationAuthenticator) VerifyReply(reply *AuthReply) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.keys == nil {
+		return ErrNoSessionKeys
+	}
+
+	if o.challenge == nil {
+		return fmt.Errorf("%w: no outstanding challenge", ErrAuthentication)
+	}
+
+	if reply.Sequence != o.challengeSequence || reply.User != o.User {
+		return fmt.Errorf("%w: reply to challenge %d of user %d, expected %d of user %d",
+			ErrAuthentication, reply.Sequence, reply.User, o.challengeSequence, o.User)
+	}
+
+	mac, err := authMAC(o.Algorithm, o.keys.Control, o.challenge, o.asdu)
+	if err != nil {
+		return err
+	}
+
+	o.challenge, o.asdu = nil, nil
+
+	if !hmac.Equal(mac, reply.MAC) {
+		return fmt.Errorf("%w: reply MAC does not match", ErrAuthentication)
+	}
+
+	return nil
+}
+
+// Keys returns the session keys in use, or nil when there are none.
+func (o *OutstationAuthenticator) Keys() *SessionKeys {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.keys
+}
+
+// nextStatus returns a new session key status with the next key change
+// sequence number and fresh challenge data, and remembers it for the key
+// change that answers it.
+func (o *OutstationAuthenticator) nextStatus(mac []byte) (*AuthKeyStatus, error) {
+	data, err := o.challengeData()
+	if err != nil {
+		return nil, err
+	}
+
+	if o.status == 0 {
+		o.status = KeyStatusNotInit
+	}
+
+	o.keySequence++
+
+	status := &AuthKeyStatus{
+		KeySequence: o.keySequence,
+		User:        o.User,
+		KeyWrap:     o.KeyWrap,
+		Status:      o.status,
+		Algorithm:   o.Algorithm,
+		Challenge:   data,
+		MAC:         mac,
+	}
+	o.lastStatus = status.encodeBody()
+
+	return status, nil
+}
+
+// unwrapChange returns the session keys of change, checking that it answers
+// the last session key status.
+func (o *OutstationAuthenticator) unwrapChange(change *AuthKeyChange) (*SessionKeys, error) {
+	if o.lastStatus == nil {
+		return nil, fmt.Errorf("%w: session key change without a key status", ErrAuthentication)
+	}
+
+	if change.KeySequence != o.keySequence || change.User != o.User {
+		return nil, fmt.Errorf("%w: key change %d of user %d, expected %d of user %d",
+			ErrAuthentication, change.KeySequence, change.User, o.keySequence, o.User)
+	}
+
+	size, err := keyWrapSize(o.KeyWrap, o.UpdateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := unwrapKey(o.UpdateKey, change.WrappedKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 2+2*size+len(o.lastStatus) || int(binary.LittleEndian.Uint16(data[0:2])) != size {
+		return nil, fmt.Errorf("%w: wrapped key data is malformed", ErrAuthentication)
+	}
+
+	keys := &SessionKeys{
+		Control: slices.Clone(data[2 : 2+size]),
+		Monitor: slices.Clone(data[2+size : 2+2*size]),
+	}
+
+	if !hmac.Equal(data[2+2*size:2+2*size+len(o.lastStatus)], o.lastStatus) {
+		return nil, fmt.Errorf("%w: wrapped key status does not match", ErrAuthentication)
+	}
+
+	return keys, nil
+}
+
+func (o *OutstationAuthenticator) challengeData() ([]byte, error) {
+	size := o.ChallengeDataSize
+	if size == 0 {
+		size = DefaultChallengeDataSize
+	}
+
+	data := make([]byte, size)
+
+	_, err := io.ReadFull(authRand(o.Rand), data)
+	if err != nil {
+		return nil, fmt.Errorf("generating challenge data: %w", err)
+	}
+
+	return data, nil
+}
+
+// --- Cryptography ---
+
+func authRand(source io.Reader) io.Reader {
+	if source == nil {
+		return rand.Reader
+	}
+
+	return source
+}
+
+// authMAC returns the HMAC-SHA256 of data under key, truncated for
+// algorithm.
+func authMAC(algorithm MACAlgorithm, key []byte, data ...[]byte) ([]byte, error) {
+	var size int
+
+	switch algorithm {
+	case MACSHA256Truncated8:
+		size = 8
+	case MACSHA256Truncated16:
+		size = 16
+	case MACSHA1Truncated4, MACSHA1Truncated10, MACSHA1Truncated8, MACAESGMAC:
+		return nil, fmt.Errorf("%w: %s", ErrMACAlgorithm, algorithm)
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrMACAlgorithm, algorithm)
+	}
+
+	mac := hmac.New(sha256.New, key)
+
+	for _, part := range data {
+		mac.Write(part)
+	}
+
+	return mac.Sum(nil)[:size], nil
+}
+
+// keyWrapSize returns the length of the update key, and of each session
+// key, for algorithm.
+func keyWrapSize(algorithm KeyWrapAlgorithm, updateKey []byte) (int, error) {
+	var size int
+
+	switch algorithm {
+	case KeyWrapAES128:
+		size = 16
+	case KeyWrapAES256:
+		size = 32
+	default:
+		return 0, fmt.Errorf("%w: %d", ErrKeyWrapAlgorithm, algorithm)
+	}
+
+	if len(updateKey) != size {
+		return 0, fmt.Errorf("%w: %s requires a %d-byte update key, got %d",
+			ErrKeyWrapAlgorithm, algorithm, size, len(updateKey))
+	}
+
+	return size, nil
+}
+
+// keyWrapData returns the data a session key change wraps: the key length,
+// the control and monitor direction keys, and the key status being answered,
+// padded to the 8-byte blocks of key wrap.
+func keyWrapData(keys *SessionKeys, status []byte) []byte {
+	//nolint:gosec // G115 - session keys are 16 or 32 bytes
+	data := binary.LittleEndian.AppendUint16(nil, uint16(len(keys.Control)))
+	data = append(data, keys.Control...)
+	data = append(data, keys.Monitor...)
+	data = append(data, status...)
+
+	if pad := len(data) % 8; pad != 0 {
+		data = append(data, make([]byte, 8-pad)...)
+	}
+
+	return data
+}
+
+// wrapKey wraps plaintext, a multiple of 8 bytes, with RFC 3394 AES key wrap.
+func wrapKey(kek, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyWrapAlgorithm, err)
+	}
+
+	n := len(plaintext) / 8
+	out := append(slices.Clone(keyWrapIV), plaintext...)
+	buf := make([]byte, aes.BlockSize)
+
+	for j := range 6 {
+		for i := 1; i <= n; i++ {
+			copy(buf[:8], out[:8])
+			copy(buf[8:], out[i*8:(i+1)*8])
+			block.Encrypt(buf, buf)
+
+			//nolint:gosec // G115 - the step counter is positive
+			binary.BigEndian.PutUint64(out[:8], binary.BigEndian.Uint64(buf[:8])^uint64(n*j+i))
+			copy(out[i*8:], buf[8:])
+		}
+	}
+
+	return out, nil
+}
+
+// unwrapKey reverses wrapKey, failing if the integrity check value doesn't
+// match (a wrong key or tampered data).
+func unwrapKey(kek, ciphertext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyWrapAlgorithm, err)
+	}
+
+	if len(ciphertext) < 24 || len(ciphertext)%8 != 0 {
+		return nil, fmt.Errorf("%w: wrapped keys of %d bytes", ErrAuthentication, len(ciphertext))
+	}
+
+	n := len(ciphertext)/8 - 1
+	check := slices.Clone(ciphertext[:8])
+	out := slices.Clone(ciphertext[8:])
+	buf := make([]byte, aes.BlockSize)
+
+	for j := 5; j >= 0; j-- {
+		for i := n; i >= 1; i-- {
+			//nolint:gosec // G115 - the step counter is positive
+			binary.BigEndian.PutUint64(buf[:8], binary.BigEndian.Uint64(check)^uint64(n*j+i))
+			copy(buf[8:], out[(i-1)*8:i*8])
+			block.Decrypt(buf, buf)
+
+			copy(check, buf[:8])
+			copy(out[(i-1)*8:], buf[8:])
+		}
+	}
+
+	if subtle.ConstantTimeCompare(check, keyWrapIV) != 1 {
+		return nil, fmt.Errorf("%w: wrapped keys failed the integrity check", ErrAuthentication)
+	}
+
+	return out, nil
+}
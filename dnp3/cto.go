@@ -0,0 +1,82 @@
+package dnp3
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoCTO is returned by ApplicationData.ResolveCTO for relative-time
+// points that no common time of occurrence (CTO) object precedes.
+var ErrNoCTO = errors.New("relative time without a preceding CTO")
+
+// ResolvedTime is the absolute time of a relative-time point: the time of
+// the CTO object before it plus its offset.
+type ResolvedTime struct {
+	Time AbsoluteTime `json:"time"`
+	// Synchronized is true when the CTO was Group 51 Var 1 (synchronized)
+	// and false for Group 51 Var 2 (unsynchronized).
+	Synchronized bool `json:"synchronized"`
+}
+
+func (rt *ResolvedTime) String() string {
+	if rt.Synchronized {
+		return fmt.Sprintf("%s (synchronized)", rt.Time.String())
+	}
+
+	return fmt.Sprintf("%s (unsynchronized)", rt.Time.String())
+}
+
+// ResolveCTO sets the ResolvedTime of every relative-time point (such as
+// Group 2 Var 3 and Group 4 Var 3 events) from the Group 51 CTO object that
+// most recently precedes it in Objects. Points with no CTO before them are
+// left unresolved and reported in an error wrapping ErrNoCTO.
+func (ad *ApplicationData) ResolveCTO() error {
+	var (
+		cto        *ResolvedTime
+		unresolved []string
+	)
+
+	for _, object := range ad.Objects {
+		header := object.Header
+
+		switch {
+		case header.Group == 51 && (header.Variation == 1 || header.Variation == 2):
+			if len(object.Points) == 0 {
+				continue
+			}
+
+			absTime, err := object.Points[0].GetAbsTime()
+			if err != nil {
+				return fmt.Errorf("reading CTO g51v%d: %w", header.Variation, err)
+			}
+
+			cto = &ResolvedTime{Time: absTime, Synchronized: header.Variation == 1}
+		case header.Group == 52:
+			// Time delays are relative to nothing in the fragment.
+		default:
+			for _, point := range object.Points {
+				bytesPoint, ok := point.(*PointBytes)
+				if !ok || bytesPoint.RelativeTime == nil {
+					continue
+				}
+
+				if cto == nil {
+					unresolved = append(unresolved, fmt.Sprintf("g%dv%d", header.Group, header.Variation))
+
+					break
+				}
+
+				bytesPoint.ResolvedTime = &ResolvedTime{
+					Time:         AbsoluteTime(cto.Time.Time().Add(bytesPoint.RelativeTime.Duration())),
+					Synchronized: cto.Synchronized,
+				}
+			}
+		}
+	}
+
+	if len(unresolved) > 0 {
+		return fmt.Errorf("%w: %v", ErrNoCTO, unresolved)
+	}
+
+	return nil
+}
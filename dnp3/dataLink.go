@@ -2,6 +2,7 @@ package dnp3
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"slices"
 )
@@ -37,6 +38,13 @@ func NewDataLinkFromBytes(data []byte) (*DataLink, error) {
 }
 
 func (dl *DataLink) DecodeFromBytes(data []byte) error {
+	return dl.decodeWithOptions(data, strictDecoding)
+}
+
+// decodeWithOptions is DecodeFromBytes with opts. Without
+// opts.Strict, a bad header CRC and unknown function codes or FCV are
+// accepted as received.
+func (dl *DataLink) decodeWithOptions(data []byte, opts DecodeOptions) error {
 	if data[0] != 0x05 || data[1] != 0x64 {
 		return fmt.Errorf(
 			"first 2 bytes %#X don't match the magic bytes (0x0564)", data[:2])
@@ -44,15 +52,18 @@ func (dl *DataLink) DecodeFromBytes(data []byte) error {
 
 	crc := CalculateDNP3CRC(data[:8])
 	if !slices.Equal(crc, data[8:10]) {
-		return fmt.Errorf(
-			"data link checksum %#X doesn't match CRC (%#X)", crc, data[8:10])
+		err := opts.tolerate("data link", data[:10], fmt.Errorf(
+			"data link checksum %#X doesn't match CRC (%#X)", crc, data[8:10]))
+		if err != nil {
+			return err
+		}
 	}
 
 	dl.Synchronize = [2]byte{0x05, 0x64}
 
 	dl.Length = uint16(data[2])
 
-	err := dl.Control.FromByte(data[3])
+	err := dl.Control.fromByte(data[3], opts)
 	if err != nil {
 		return err
 	}
@@ -107,40 +118,51 @@ func (dl *DataLink) String() string {
 type DataLinkControl struct {
 	Direction       bool             `json:"direction"`
 	Primary         bool             `json:"primary"`
-	FrameCountBit   bool             `json:"frame_count_bit"`   // ignoring checks to enforce dl to 0
-	FrameCountValid bool             `json:"frame_count_valid"` // ignoring use of DFC
+	FrameCountBit   bool             `json:"frame_count_bit"`   // see LinkLayer
+	FrameCountValid bool             `json:"frame_count_valid"` // DFC when not Primary
 	FunctionCode    DataLinkFunction `json:"function_code"`     // only 4 bits
 }
 
 func (dlctl *DataLinkControl) FromByte(value byte) error {
+	return dlctl.fromByte(value, strictDecoding)
+}
+
+// fromByte is FromByte with opts. Without opts.Strict, FunctionCode keeps a
+// code that is unknown or used with the wrong FCV.
+func (dlctl *DataLinkControl) fromByte(value byte, opts DecodeOptions) error {
 	dlctl.Direction = (value & 0b10000000) != 0
 	dlctl.Primary = (value & 0b01000000) != 0
 	dlctl.FrameCountBit = (value & 0b00100000) != 0
 	dlctl.FrameCountValid = (value & 0b00010000) != 0
 
+	var err error
+
 	functionCode := value & 0b00001111
 	if dlctl.Primary {
 		code := DataLinkPrimaryFunctionCode(functionCode)
-		if !isValidPrimaryFunctionCode(code) {
-			return fmt.Errorf("unknown primary function code 0x%X", functionCode)
-		}
+		dlctl.FunctionCode = code
 
-		if !checkPrimaryFunctionCodeFCVValidity(code, dlctl.FrameCountValid) {
-			return fmt.Errorf(
+		switch {
+		case !isValidPrimaryFunctionCode(code):
+			err = fmt.Errorf("unknown primary function code 0x%X", functionCode)
+		case !checkPrimaryFunctionCodeFCVValidity(code, dlctl.FrameCountValid):
+			err = fmt.Errorf(
 				"invalid FCV value %t for primary function code 0x%X",
 				dlctl.FrameCountValid,
 				functionCode,
 			)
 		}
-
-		dlctl.FunctionCode = code
 	} else {
 		code := DataLinkSecondaryFunctionCode(functionCode)
+		dlctl.FunctionCode = code
+
 		if !isValidSecondaryFunctionCode(code) {
-			return fmt.Errorf("unknown secondary function code 0x%X", functionCode)
+			err = fmt.Errorf("unknown secondary function code 0x%X", functionCode)
 		}
+	}
 
-		dlctl.FunctionCode = code
+	if err != nil {
+		return opts.tolerate("data link", []byte{value}, err)
 	}
 
 	return nil
@@ -198,6 +220,43 @@ func (dlctl *DataLinkControl) String() string {
 	)
 }
 
+// UnmarshalJSON rebuilds FunctionCode as a DataLinkPrimaryFunctionCode or
+// DataLinkSecondaryFunctionCode according to the PRM bit. Codes are not
+// validated, so any value MarshalJSON produced is accepted.
+func (dlctl *DataLinkControl) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Direction       bool   `json:"direction"`
+		Primary         bool   `json:"primary"`
+		FrameCountBit   bool   `json:"frame_count_bit"`
+		FrameCountValid bool   `json:"frame_count_valid"`
+		FunctionCode    *uint8 `json:"function_code"`
+	}
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal data link control: %w", err)
+	}
+
+	*dlctl = DataLinkControl{
+		Direction:       raw.Direction,
+		Primary:         raw.Primary,
+		FrameCountBit:   raw.FrameCountBit,
+		FrameCountValid: raw.FrameCountValid,
+	}
+
+	if raw.FunctionCode == nil {
+		return nil
+	}
+
+	if raw.Primary {
+		dlctl.FunctionCode = DataLinkPrimaryFunctionCode(*raw.FunctionCode)
+	} else {
+		dlctl.FunctionCode = DataLinkSecondaryFunctionCode(*raw.FunctionCode)
+	}
+
+	return nil
+}
+
 type DataLinkFunction interface {
 	fmt.Stringer
 	Byte() byte
@@ -0,0 +1,703 @@
+package dnp3
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// PointDataTypeDataSet identifies a data set (Groups 85-88) object.
+const PointDataTypeDataSet PointDataType = "data-set"
+
+// ErrUnknownDataSet is returned by DataSetRegistry.Resolve for data set
+// values whose descriptor, or a prototype it uses, isn't known.
+var ErrUnknownDataSet = errors.New("unknown data set")
+
+// DataSetDescriptorCode is the descriptor code of a data set element, which
+// says what the element describes.
+//
+//go:generate stringer -type=DataSetDescriptorCode -trimprefix=DataSet
+type DataSetDescriptorCode uint8
+
+const (
+	DataSetID   DataSetDescriptorCode = 1 // identifier
+	DataSetUUID DataSetDescriptorCode = 2 // universally unique identifier
+	DataSetNSPC DataSetDescriptorCode = 3 // namespace
+	DataSetNAME DataSetDescriptorCode = 4 // name
+	DataSetDAEL DataSetDescriptorCode = 5 // data element
+	DataSetPTYP DataSetDescriptorCode = 6 // prototype, named by its UUID
+	DataSetCTLV DataSetDescriptorCode = 7 // control value
+	DataSetCTLS DataSetDescriptorCode = 8 // control status
+)
+
+// hasValue reports whether an element of this code has a value in the data
+// set present value and snapshot objects.
+func (c DataSetDescriptorCode) hasValue() bool {
+	return c == DataSetDAEL || c == DataSetCTLV || c == DataSetCTLS
+}
+
+// DataSetElement is an element of a data set prototype or descriptor. The
+// data type codes are those of the device attributes; the ancillary value is
+// the element's name, or the UUID of the prototype a PTYP element uses.
+type DataSetElement struct {
+	Code      DataSetDescriptorCode `json:"code"`
+	Type      AttributeDataType     `json:"type"`
+	MaxLength uint8                 `json:"max_length"`
+	Ancillary []byte                `json:"ancillary"`
+}
+
+func (e *DataSetElement) String() string {
+	return fmt.Sprintf("%s %s (max %d) %q", e.Code, e.Type, e.MaxLength, e.Ancillary)
+}
+
+// decodeDataSetElements decodes the length-prefixed elements that fill data.
+func decodeDataSetElements(data []byte) ([]DataSetElement, error) {
+	var elements []DataSetElement
+
+	for offset := 0; offset < len(data); {
+		length := int(data[offset])
+		if length < 3 || len(data) < offset+1+length {
+			return elements, fmt.Errorf("data set element %d of %d bytes, got %d",
+				len(elements), length, len(data)-offset-1)
+		}
+
+		element := data[offset+1 : offset+1+length]
+		elements = append(elements, DataSetElement{
+			Code:      DataSetDescriptorCode(element[0]),
+			Type:      AttributeDataType(element[1]),
+			MaxLength: element[2],
+			Ancillary: slices.Clone(element[3:]),
+		})
+		offset += 1 + length
+	}
+
+	return elements, nil
+}
+
+func encodeDataSetElements(elements []DataSetElement) ([]byte, error) {
+	var output []byte
+
+	for _, element := range elements {
+		if len(element.Ancillary) > math.MaxUint8-3 {
+			return nil, fmt.Errorf("data set element ancillary value of %d bytes exceeds 252", len(element.Ancillary))
+		}
+
+		output = append(output, byte(3+len(element.Ancillary)), byte(element.Code), byte(element.Type), element.MaxLength)
+		output = append(output, element.Ancillary...)
+	}
+
+	return output, nil
+}
+
+// decodeDataSetValues decodes the length-prefixed values that fill data.
+func decodeDataSetValues(data []byte) ([][]byte, error) {
+	var values [][]byte
+
+	for offset := 0; offset < len(data); {
+		length := int(data[offset])
+		if len(data) < offset+1+length {
+			return values, fmt.Errorf("data set value %d of %d bytes, got %d",
+				len(values), length, len(data)-offset-1)
+		}
+
+		values = append(values, slices.Clone(data[offset+1:offset+1+length]))
+		offset += 1 + length
+	}
+
+	return values, nil
+}
+
+func encodeDataSetValues(values [][]byte) ([]byte, error) {
+	var output []byte
+
+	for _, value := range values {
+		if len(value) > math.MaxUint8 {
+			return nil, fmt.Errorf("data set value of %d bytes exceeds 255", len(value))
+		}
+
+		output = append(output, byte(len(value)))
+		output = append(output, value...)
+	}
+
+	return output, nil
+}
+
+func dataSetElementsString(elements []DataSetElement) []string {
+	parts := make([]string, 0, len(elements))
+	for _, element := range elements {
+		parts = append(parts, "- "+element.String())
+	}
+
+	return parts
+}
+
+// dataSetObject is the base of the size-prefixed data set objects.
+type dataSetObject struct {
+	sizedObject
+}
+
+func (d *dataSetObject) DataType() PointDataType { return PointDataTypeDataSet }
+
+// DataSetPrototype (Group 85 Var 1) defines a reusable group of elements,
+// which data set descriptors include with a PTYP element naming its UUID.
+type DataSetPrototype struct {
+	dataSetObject
+
+	ID       uint16           `json:"id"`
+	UUID     [16]byte         `json:"uuid"`
+	Elements []DataSetElement `json:"elements"`
+}
+
+func (p *DataSetPrototype) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 18 {
+		return fmt.Errorf("data set prototype requires at least 18 bytes, got %d", len(body))
+	}
+
+	p.ID = binary.LittleEndian.Uint16(body[0:2])
+	copy(p.UUID[:], body[2:18])
+
+	p.Elements, err = decodeDataSetElements(body[18:])
+
+	return err
+}
+
+func (p *DataSetPrototype) SerializeTo() ([]byte, error) {
+	elements, err := encodeDataSetElements(p.Elements)
+	if err != nil {
+		return nil, err
+	}
+
+	body := binary.LittleEndian.AppendUint16(nil, p.ID)
+	body = append(body, p.UUID[:]...)
+
+	return p.prefixed(append(body, elements...))
+}
+
+func (p *DataSetPrototype) String() string {
+	return strings.Join(append([]string{
+		fmt.Sprintf("ID      : %d", p.ID),
+		fmt.Sprintf("UUID    : 0x % X", p.UUID),
+		"Elements:",
+	}, dataSetElementsString(p.Elements)...), "\n")
+}
+
+func (p *DataSetPrototype) GetValue() any { return *p }
+
+func (p *DataSetPrototype) SetValue(value any) error {
+	val, ok := value.(DataSetPrototype)
+	if !ok {
+		return fmt.Errorf("data set prototype value must be a DataSetPrototype, got %T", value)
+	}
+
+	val.dataSetObject = p.dataSetObject
+	*p = val
+
+	return nil
+}
+
+// DataSetDescriptor (Group 86 Var 1) defines the elements of the data set
+// with an ID. Its values are carried by DataSetValue and DataSetEvent.
+type DataSetDescriptor struct {
+	dataSetObject
+
+	ID       uint16           `json:"id"`
+	Elements []DataSetElement `json:"elements"`
+}
+
+func (p *DataSetDescriptor) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 2 {
+		return fmt.Errorf("data set descriptor requires at least 2 bytes, got %d", len(body))
+	}
+
+	p.ID = binary.LittleEndian.Uint16(body[0:2])
+
+	p.Elements, err = decodeDataSetElements(body[2:])
+
+	return err
+}
+
+func (p *DataSetDescriptor) SerializeTo() ([]byte, error) {
+	elements, err := encodeDataSetElements(p.Elements)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.prefixed(append(binary.LittleEndian.AppendUint16(nil, p.ID), elements...))
+}
+
+func (p *DataSetDescriptor) String() string {
+	return strings.Join(append([]string{
+		fmt.Sprintf("ID      : %d", p.ID),
+		"Elements:",
+	}, dataSetElementsString(p.Elements)...), "\n")
+}
+
+func (p *DataSetDescriptor) GetValue() any { return *p }
+
+func (p *DataSetDescriptor) SetValue(value any) error {
+	val, ok := value.(DataSetDescriptor)
+	if !ok {
+		return fmt.Errorf("data set descriptor value must be a DataSetDescriptor, got %T", value)
+	}
+
+	val.dataSetObject = p.dataSetObject
+	*p = val
+
+	return nil
+}
+
+// DataSetPointIndex is a point associated with a data set: its point type
+// code and its index.
+type DataSetPointIndex struct {
+	Type  uint8 `json:"type"`
+	Index int   `json:"index"`
+
+	// indexSize is the width of Index as received. Zero encodes it in the
+	// fewest of 1, 2 or 4 octets.
+	indexSize int
+}
+
+func (i *DataSetPointIndex) String() string {
+	return fmt.Sprintf("type %d, index %d", i.Type, i.Index)
+}
+
+// DataSetPointIndexes (Group 86 Var 3) lists the points associated with the
+// data set with an ID.
+type DataSetPointIndexes struct {
+	dataSetObject
+
+	ID     uint16              `json:"id"`
+	Points []DataSetPointIndex `json:"points"`
+}
+
+func (p *DataSetPointIndexes) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 2 {
+		return fmt.Errorf("data set point index attributes require at least 2 bytes, got %d", len(body))
+	}
+
+	p.ID = binary.LittleEndian.Uint16(body[0:2])
+	p.Points = nil
+
+	// Each attribute is preceded by its length: a type octet, then the index.
+	for offset := 2; offset < len(body); {
+		length := int(body[offset])
+		if length < 2 || length > 5 || len(body) < offset+1+length {
+			return fmt.Errorf("data set point index attribute %d of %d bytes, got %d",
+				len(p.Points), length, len(body)-offset-1)
+		}
+
+		attribute := body[offset+1 : offset+1+length]
+
+		index, err := prefixToInt(attribute[1:])
+		if err != nil {
+			return fmt.Errorf("data set point index attribute %d: %w", len(p.Points), err)
+		}
+
+		p.Points = append(p.Points, DataSetPointIndex{Type: attribute[0], Index: index, indexSize: length - 1})
+		offset += 1 + length
+	}
+
+	return nil
+}
+
+func (p *DataSetPointIndexes) SerializeTo() ([]byte, error) {
+	body := binary.LittleEndian.AppendUint16(nil, p.ID)
+
+	for _, point := range p.Points {
+		size := point.indexSize
+		if size == 0 {
+			switch {
+			case point.Index <= math.MaxUint8:
+				size = 1
+			case point.Index <= math.MaxUint16:
+				size = 2
+			default:
+				size = 4
+			}
+		}
+
+		index, err := intToPrefixSized(point.Index, size)
+		if err != nil {
+			return nil, fmt.Errorf("data set point index: %w", err)
+		}
+
+		body = append(body, byte(1+size), point.Type)
+		body = append(body, index...)
+	}
+
+	return p.prefixed(body)
+}
+
+func (p *DataSetPointIndexes) String() string {
+	parts := []string{fmt.Sprintf("ID    : %d", p.ID), "Points:"}
+	for _, point := range p.Points {
+		parts = append(parts, "- "+point.String())
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+func (p *DataSetPointIndexes) GetValue() any { return *p }
+
+func (p *DataSetPointIndexes) SetValue(value any) error {
+	val, ok := value.(DataSetPointIndexes)
+	if !ok {
+		return fmt.Errorf("data set point index attributes value must be a DataSetPointIndexes, got %T", value)
+	}
+
+	val.dataSetObject = p.dataSetObject
+	*p = val
+
+	return nil
+}
+
+// DataSetField is a data set value matched with the element that describes
+// it, as set by DataSetRegistry.Resolve.
+type DataSetField struct {
+	Name string                `json:"name"`
+	Code DataSetDescriptorCode `json:"code"`
+	Type AttributeDataType     `json:"type"`
+	// Value is the value as the Go type its data type implies (see
+	// DeviceAttribute.Interpret), or the raw bytes.
+	Value any `json:"value"`
+}
+
+func (f *DataSetField) String() string {
+	return fmt.Sprintf("%s (%s %s): %v", f.Name, f.Code, f.Type, f.Value)
+}
+
+// DataSetValue (Group 87 Var 1) is the present value of the data set with an
+// ID: one value per DAEL, CTLV and CTLS element of its descriptor, with
+// prototypes expanded. Values only have a type once DataSetRegistry.Resolve
+// has set Resolved.
+type DataSetValue struct {
+	dataSetObject
+
+	ID     uint16   `json:"id"`
+	Values [][]byte `json:"values"`
+	// Resolved is set by DataSetRegistry.Resolve. It is ignored when
+	// encoding.
+	Resolved []DataSetField `json:"resolved,omitempty"`
+}
+
+func (p *DataSetValue) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 2 {
+		return fmt.Errorf("data set present value requires at least 2 bytes, got %d", len(body))
+	}
+
+	p.ID = binary.LittleEndian.Uint16(body[0:2])
+
+	p.Values, err = decodeDataSetValues(body[2:])
+
+	return err
+}
+
+func (p *DataSetValue) SerializeTo() ([]byte, error) {
+	values, err := encodeDataSetValues(p.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.prefixed(append(binary.LittleEndian.AppendUint16(nil, p.ID), values...))
+}
+
+func (p *DataSetValue) String() string {
+	return strings.Join(append([]string{fmt.Sprintf("ID    : %d", p.ID)},
+		dataSetValuesString(p.Values, p.Resolved)...), "\n")
+}
+
+func (p *DataSetValue) GetValue() any { return *p }
+
+func (p *DataSetValue) SetValue(value any) error {
+	val, ok := value.(DataSetValue)
+	if !ok {
+		return fmt.Errorf("data set present value must be a DataSetValue, got %T", value)
+	}
+
+	val.dataSetObject = p.dataSetObject
+	*p = val
+
+	return nil
+}
+
+// DataSetEvent (Group 88 Var 1) is a snapshot of the data set with an ID,
+// taken at Time. Its values are laid out as in DataSetValue.
+type DataSetEvent struct {
+	dataSetObject
+
+	ID     uint16       `json:"id"`
+	Time   AbsoluteTime `json:"time"`
+	Values [][]byte     `json:"values"`
+	// Resolved is set by DataSetRegistry.Resolve. It is ignored when
+	// encoding.
+	Resolved []DataSetField `json:"resolved,omitempty"`
+}
+
+func (p *DataSetEvent) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 8 {
+		return fmt.Errorf("data set snapshot requires at least 8 bytes, got %d", len(body))
+	}
+
+	p.ID = binary.LittleEndian.Uint16(body[0:2])
+
+	p.Time, err = BytesToDNP3TimeAbsolute(body[2:8])
+	if err != nil {
+		return fmt.Errorf("time of snapshot: %w", err)
+	}
+
+	p.Values, err = decodeDataSetValues(body[8:])
+
+	return err
+}
+
+func (p *DataSetEvent) SerializeTo() ([]byte, error) {
+	snapshotTime, err := TimeAbsoluteToBytes(p.Time)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode time of snapshot: %w", err)
+	}
+
+	values, err := encodeDataSetValues(p.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	body := binary.LittleEndian.AppendUint16(nil, p.ID)
+	body = append(body, snapshotTime...)
+
+	return p.prefixed(append(body, values...))
+}
+
+func (p *DataSetEvent) String() string {
+	return strings.Join(append([]string{
+		fmt.Sprintf("ID    : %d", p.ID),
+		"Time  : " + p.Time.String(),
+	}, dataSetValuesString(p.Values, p.Resolved)...), "\n")
+}
+
+func (p *DataSetEvent) GetValue() any { return *p }
+
+func (p *DataSetEvent) SetValue(value any) error {
+	val, ok := value.(DataSetEvent)
+	if !ok {
+		return fmt.Errorf("data set snapshot value must be a DataSetEvent, got %T", value)
+	}
+
+	val.dataSetObject = p.dataSetObject
+	*p = val
+
+	return nil
+}
+
+func dataSetValuesString(values [][]byte, resolved []DataSetField) []string {
+	parts := []string{"Values:"}
+
+	if resolved != nil {
+		for _, field := range resolved {
+			parts = append(parts, "- "+field.String())
+		}
+
+		return parts
+	}
+
+	for _, value := range values {
+		parts = append(parts, fmt.Sprintf("- 0x % X", value))
+	}
+
+	return parts
+}
+
+// --- Registry ---
+
+// DataSetRegistry holds the data set prototypes and descriptors a device
+// uses, which are needed to make sense of data set values: the objects only
+// carry the value bytes, and decoding sees one object at a time.
+type DataSetRegistry struct {
+	mu          sync.RWMutex
+	prototypes  map[[16]byte]*DataSetPrototype
+	descriptors map[uint16]*DataSetDescriptor
+}
+
+// NewDataSetRegistry returns an empty DataSetRegistry.
+func NewDataSetRegistry() *DataSetRegistry {
+	return &DataSetRegistry{
+		prototypes:  make(map[[16]byte]*DataSetPrototype),
+		descriptors: make(map[uint16]*DataSetDescriptor),
+	}
+}
+
+// AddPrototype adds or replaces the prototype with the UUID of prototype.
+func (r *DataSetRegistry) AddPrototype(prototype *DataSetPrototype) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.prototypes[prototype.UUID] = prototype
+}
+
+// AddDescriptor adds or replaces the descriptor with the ID of descriptor.
+func (r *DataSetRegistry) AddDescriptor(descriptor *DataSetDescriptor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.descriptors[descriptor.ID] = descriptor
+}
+
+// Learn adds every prototype (Group 85) and descriptor (Group 86 Var 1) in
+// ad, such as the response to a read of them.
+func (r *DataSetRegistry) Learn(ad *ApplicationData) {
+	for _, object := range ad.Objects {
+		for _, point := range object.Points {
+			switch dataSet := point.(type) {
+			case *DataSetPrototype:
+				r.AddPrototype(dataSet)
+			case *DataSetDescriptor:
+				r.AddDescriptor(dataSet)
+			}
+		}
+	}
+}
+
+// Elements returns the elements of the data set with id that have a value,
+// in the order of the values, with the elements of its prototypes in place
+// of the PTYP elements.
+func (r *DataSetRegistry) Elements(id uint16) ([]DataSetElement, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	descriptor, ok := r.descriptors[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: no descriptor for data set %d", ErrUnknownDataSet, id)
+	}
+
+	var elements []DataSetElement
+
+	for _, element := range descriptor.Elements {
+		if element.Code != DataSetPTYP {
+			if element.Code.hasValue() {
+				elements = append(elements, element)
+			}
+
+			continue
+		}
+
+		var uuid [16]byte
+		if len(element.Ancillary) != len(uuid) {
+			return nil, fmt.Errorf("data set %d: PTYP element with a %d-byte UUID", id, len(element.Ancillary))
+		}
+
+		copy(uuid[:], element.Ancillary)
+
+		prototype, ok := r.prototypes[uuid]
+		if !ok {
+			return nil, fmt.Errorf("%w: data set %d uses unknown prototype 0x % X", ErrUnknownDataSet, id, uuid)
+		}
+
+		for _, protoElement := range prototype.Elements {
+			if protoElement.Code.hasValue() {
+				elements = append(elements, protoElement)
+			}
+		}
+	}
+
+	return elements, nil
+}
+
+// Resolve learns the prototypes and descriptors in ad, then sets Resolved on
+// every data set present value (Group 87) and snapshot (Group 88) in it,
+// typing each value by its element. Values of unknown data sets, or that
+// don't match their descriptor, are left unresolved and reported in the
+// returned error.
+func (r *DataSetRegistry) Resolve(ad *ApplicationData) error {
+	r.Learn(ad)
+
+	var errs []error
+
+	for _, object := range ad.Objects {
+		for _, point := range object.Points {
+			var (
+				id       uint16
+				values   [][]byte
+				resolved *[]DataSetField
+			)
+
+			switch dataSet := point.(type) {
+			case *DataSetValue:
+				id, values, resolved = dataSet.ID, dataSet.Values, &dataSet.Resolved
+			case *DataSetEvent:
+				id, values, resolved = dataSet.ID, dataSet.Values, &dataSet.Resolved
+			default:
+				continue
+			}
+
+			fields, err := r.resolve(id, values)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("g%dv%d: %w", object.Header.Group, object.Header.Variation, err))
+
+				continue
+			}
+
+			*resolved = fields
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *DataSetRegistry) resolve(id uint16, values [][]byte) ([]DataSetField, error) {
+	elements, err := r.Elements(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(elements) != len(values) {
+		return nil, fmt.Errorf("data set %d has %d elements, got %d values", id, len(elements), len(values))
+	}
+
+	fields := make([]DataSetField, 0, len(values))
+
+	for i, element := range elements {
+		var value any = slices.Clone(values[i])
+
+		interpreted, err := (&DeviceAttribute{Type: element.Type, Value: values[i]}).Interpret()
+		if err == nil {
+			value = interpreted
+		}
+
+		fields = append(fields, DataSetField{
+			Name:  string(element.Ancillary),
+			Code:  element.Code,
+			Type:  element.Type,
+			Value: value,
+		})
+	}
+
+	return fields, nil
+}
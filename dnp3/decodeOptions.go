@@ -0,0 +1,58 @@
+package dnp3
+
+import "fmt"
+
+// DecodeOptions controls how NewFrameFromBytesWithOptions and
+// Frame.DecodeFromBytesWithOptions treat malformed input. Lenient decoding is
+// only offered for whole frames, as the Frame is what collects the warnings.
+type DecodeOptions struct {
+	// Strict rejects anomalies, as DecodeFromBytes does: bad CRCs, unknown
+	// data link function codes or FCV, the reserved qualifier bit, IIN 2.6
+	// and 2.7, and objects that can't be decoded. Without it, decoding
+	// carries on past them. Fields hold what was received, bytes that can't
+	// be decoded are kept raw (ApplicationData.GetExtra, or Frame.Segment for
+	// a fragment too short to decode), and a Frame records each anomaly in
+	// Frame.Warnings.
+	Strict bool
+
+	// warnings collects the anomalies of a lenient decode, if set.
+	warnings *[]DecodeWarning
+}
+
+// strictDecoding are the options of the DecodeFromBytes decoders.
+var strictDecoding = DecodeOptions{Strict: true}
+
+// DecodeWarning is an anomaly a lenient decode let through.
+type DecodeWarning struct {
+	// Layer is "data link", "transport" or "application".
+	Layer   string `json:"layer"`
+	Message string `json:"message"`
+	// Raw holds the bytes the anomaly is in, if it is about specific bytes.
+	Raw []byte `json:"raw,omitempty"`
+}
+
+func (w DecodeWarning) String() string {
+	if len(w.Raw) == 0 {
+		return fmt.Sprintf("%s: %s", w.Layer, w.Message)
+	}
+
+	return fmt.Sprintf("%s: %s (0x % X)", w.Layer, w.Message, w.Raw)
+}
+
+// tolerate returns err when decoding strictly. Otherwise it records err as a
+// warning about raw in layer and returns nil, so decoding carries on.
+func (opts DecodeOptions) tolerate(layer string, raw []byte, err error) error {
+	if opts.Strict {
+		return err
+	}
+
+	if opts.warnings != nil {
+		*opts.warnings = append(*opts.warnings, DecodeWarning{
+			Layer:   layer,
+			Message: err.Error(),
+			Raw:     append([]byte(nil), raw...),
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,495 @@
+package dnp3
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// PointDataTypeDeviceAttribute identifies a device attribute point.
+const PointDataTypeDeviceAttribute PointDataType = "device-attribute"
+
+// AttributeDataType is the data type code that precedes the value of a
+// device attribute (Group 0).
+//
+//go:generate stringer -type=AttributeDataType -trimprefix=Attribute
+type AttributeDataType uint8
+
+const (
+	AttributeVSTR        AttributeDataType = 1   // visible string
+	AttributeUINT        AttributeDataType = 2   // unsigned integer
+	AttributeINT         AttributeDataType = 3   // signed integer
+	AttributeFLT         AttributeDataType = 4   // floating point
+	AttributeOSTR        AttributeDataType = 5   // octet string
+	AttributeBSTR        AttributeDataType = 6   // bit string
+	AttributeTIME        AttributeDataType = 7   // DNP3 absolute time
+	AttributeU8BS8LIST   AttributeDataType = 254 // list of variation, properties pairs
+	AttributeU8BS8EXLIST AttributeDataType = 255 // extended list of variation, properties pairs
+)
+
+// ErrAttributeType is returned when a device attribute is read as a type
+// its data type code doesn't hold.
+var ErrAttributeType = errors.New("device attribute has a different data type")
+
+// attributeNames are the standard device attributes (set 0) by variation.
+var attributeNames = map[uint8]string{
+	209: "Secure authentication version",
+	210: "Number of security statistics per association",
+	211: "Identification of support for user-specific attributes",
+	212: "Number of master-defined data set prototypes",
+	213: "Number of outstation-defined data set prototypes",
+	214: "Number of master-defined data sets",
+	215: "Number of outstation-defined data sets",
+	216: "Maximum number of binary output objects per request",
+	217: "Local timing accuracy",
+	218: "Duration of time accuracy",
+	219: "Support for analog output events",
+	220: "Maximum analog output index",
+	221: "Number of analog outputs",
+	222: "Support for binary output events",
+	223: "Maximum binary output index",
+	224: "Number of binary outputs",
+	225: "Support for frozen counter events",
+	226: "Support for frozen counters",
+	227: "Support for counter events",
+	228: "Maximum counter index",
+	229: "Number of counter points",
+	230: "Support for frozen analog inputs",
+	231: "Support for analog input events",
+	232: "Maximum analog input index",
+	233: "Number of analog input points",
+	234: "Support for double-bit binary input events",
+	235: "Maximum double-bit binary input index",
+	236: "Number of double-bit binary input points",
+	237: "Support for binary input events",
+	238: "Maximum binary input index",
+	239: "Number of binary input points",
+	240: "Maximum transmit fragment size",
+	241: "Maximum receive fragment size",
+	242: "Device manufacturer's software version",
+	243: "Device manufacturer's hardware version",
+	245: "User-assigned location name",
+	246: "User-assigned ID code/number",
+	247: "User-assigned device name",
+	248: "Device serial number",
+	249: "DNP3 subset and conformance",
+	250: "Device manufacturer's product name and model",
+	252: "Device manufacturer's name",
+	254: "Non-specific all attributes request",
+	255: "List of attribute variations",
+}
+
+// AttributeName returns the name of a standard device attribute variation,
+// or "" for variations outside the standard set.
+func AttributeName(variation uint8) string {
+	return attributeNames[variation]
+}
+
+// deviceAttributeType returns the objectTypes entry of a Group 0 variation.
+// The encoding is the same for all of them, so the point only differs by the
+// variation it records.
+func deviceAttributeType(variation uint8) *objectType {
+	name := AttributeName(variation)
+	if name == "" {
+		name = fmt.Sprintf("Variation %d", variation)
+	}
+
+	return &objectType{
+		Description: "(Info) Device Attributes - " + name,
+		Constructor: makeDeviceAttributeConstructor(variation),
+		Packer:      packPointsBytes,
+	}
+}
+
+// DeviceAttribute is a Group 0 device attribute: a data type code, a length
+// and a value of that length. The index is the attribute set, 0 for the
+// standard attributes. Use the As methods to interpret the value.
+type DeviceAttribute struct {
+	Index     *int `json:"index,omitempty"`
+	indexSize int
+	// Variation is the attribute the point holds, from its object header.
+	Variation uint8             `json:"variation"`
+	Type      AttributeDataType `json:"type"`
+	Value     []byte            `json:"value"`
+}
+
+func (p *DeviceAttribute) DataType() PointDataType { return PointDataTypeDeviceAttribute }
+
+func (p *DeviceAttribute) DecodeFromBytes(data []byte, prefSize int) error {
+	if len(data) < prefSize+2 {
+		return fmt.Errorf("device attribute requires at least %d bytes, got %d", prefSize+2, len(data))
+	}
+
+	if prefSize > 0 {
+		index, err := prefixToInt(data[:prefSize])
+		if err != nil {
+			return fmt.Errorf("could not decode index prefix: %w", err)
+		}
+
+		p.Index = &index
+		p.indexSize = prefSize
+	}
+
+	data = data[prefSize:]
+	length := int(data[1])
+
+	if len(data) < 2+length {
+		return fmt.Errorf("device attribute value needs %d bytes, got %d", length, len(data)-2)
+	}
+
+	p.Type = AttributeDataType(data[0])
+	p.Value = slices.Clone(data[2 : 2+length])
+
+	return nil
+}
+
+// size returns the wire size of the point, including its prefix.
+func (p *DeviceAttribute) size() int {
+	return p.indexSize + 2 + len(p.Value)
+}
+
+func (p *DeviceAttribute) SerializeTo() ([]byte, error) {
+	var output []byte
+
+	if p.indexSize > 0 {
+		indexBytes, err := intToPrefix(*p.Index, p.indexSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode index: %w", err)
+		}
+
+		output = append(output, indexBytes...)
+	}
+
+	if len(p.Value) > math.MaxUint8 {
+		return nil, fmt.Errorf("device attribute value of %d bytes exceeds 255", len(p.Value))
+	}
+
+	output = append(output, byte(p.Type), byte(len(p.Value)))
+
+	return append(output, p.Value...), nil
+}
+
+func (p *DeviceAttribute) String() string {
+	var parts []string
+
+	if p.indexSize > 0 {
+		parts = append(parts, fmt.Sprintf("Index    : %d", *p.Index))
+	}
+
+	if name := AttributeName(p.Variation); name != "" {
+		parts = append(parts, "Attribute: "+name)
+	}
+
+	parts = append(parts, fmt.Sprintf("Type     : (%d) %s", p.Type, p.Type))
+
+	value, err := p.Interpret()
+	if err == nil {
+		parts = append(parts, fmt.Sprintf("Value    : %v", value))
+	} else {
+		parts = append(parts, fmt.Sprintf("Value    : 0x % X", p.Value))
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+func (p *DeviceAttribute) Fields() PointFields {
+	return PointFields{
+		Index: p.indexSize > 0,
+		Value: true,
+	}
+}
+
+// --- Get/Set methods ---
+
+func (p *DeviceAttribute) GetIndex() (int, error) {
+	if p.indexSize == 0 {
+		return 0, ErrNoIndex
+	}
+
+	return *p.Index, nil
+}
+
+func (p *DeviceAttribute) SetIndex(value int) error {
+	return setIndex(&p.Index, &p.indexSize, value)
+}
+
+func (p *DeviceAttribute) GetFlags() (PointFlags, error)     { return PointFlags{}, ErrNoFlags }
+func (p *DeviceAttribute) SetFlags(PointFlags) error         { return ErrNoFlags }
+func (p *DeviceAttribute) GetAbsTime() (AbsoluteTime, error) { return AbsoluteTime{}, ErrNoAbsTime }
+func (p *DeviceAttribute) SetAbsTime(AbsoluteTime) error     { return ErrNoAbsTime }
+func (p *DeviceAttribute) GetRelTime() (RelativeTime, error) { return 0, ErrNoRelTime }
+func (p *DeviceAttribute) SetRelTime(RelativeTime) error     { return ErrNoRelTime }
+
+// GetValue returns the interpreted value (see Interpret), or the raw value
+// bytes if it can't be interpreted.
+func (p *DeviceAttribute) GetValue() any {
+	value, err := p.Interpret()
+	if err != nil {
+		return slices.Clone(p.Value)
+	}
+
+	return value
+}
+
+// SetValue encodes value with the matching data type: a string as VSTR,
+// unsigned integers as UINT, signed integers as INT, float32 and float64 as
+// FLT, []byte as OSTR and []AttributeVariation as U8BS8LIST.
+func (p *DeviceAttribute) SetValue(value any) error {
+	switch val := value.(type) {
+	case string:
+		return p.SetString(val)
+	case uint8:
+		return p.SetUint(uint64(val))
+	case uint16:
+		return p.SetUint(uint64(val))
+	case uint32:
+		return p.SetUint(uint64(val))
+	case uint64:
+		return p.SetUint(val)
+	case uint:
+		return p.SetUint(uint64(val))
+	case int8:
+		return p.SetInt(int64(val))
+	case int16:
+		return p.SetInt(int64(val))
+	case int32:
+		return p.SetInt(int64(val))
+	case int64:
+		return p.SetInt(val)
+	case int:
+		return p.SetInt(int64(val))
+	case float32:
+		p.Type = AttributeFLT
+		p.Value = binary.LittleEndian.AppendUint32(nil, math.Float32bits(val))
+
+		return nil
+	case float64:
+		return p.SetFloat(val)
+	case []byte:
+		if len(val) > math.MaxUint8 {
+			return fmt.Errorf("device attribute value of %d bytes exceeds 255", len(val))
+		}
+
+		p.Type = AttributeOSTR
+		p.Value = slices.Clone(val)
+
+		return nil
+	case []AttributeVariation:
+		return p.SetAttributeVariations(val)
+	default:
+		return fmt.Errorf("unsupported device attribute value type %T", value)
+	}
+}
+
+// Interpret returns the value as the Go type its data type code implies:
+// string for VSTR, uint64 for UINT, int64 for INT, float64 for FLT,
+// []AttributeVariation for U8BS8LIST and []byte for the others.
+func (p *DeviceAttribute) Interpret() (any, error) {
+	switch p.Type {
+	case AttributeVSTR:
+		return p.AsString()
+	case AttributeUINT:
+		return p.AsUint()
+	case AttributeINT:
+		return p.AsInt()
+	case AttributeFLT:
+		return p.AsFloat()
+	case AttributeU8BS8LIST:
+		return p.AttributeVariations()
+	case AttributeOSTR, AttributeBSTR, AttributeTIME, AttributeU8BS8EXLIST:
+		return slices.Clone(p.Value), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown data type code %d", ErrAttributeType, p.Type)
+	}
+}
+
+// AsString returns a VSTR attribute, such as the manufacturer's name (252) or
+// software version (242).
+func (p *DeviceAttribute) AsString() (string, error) {
+	if p.Type != AttributeVSTR {
+		return "", fmt.Errorf("%w: %s, not VSTR", ErrAttributeType, p.Type)
+	}
+
+	return string(p.Value), nil
+}
+
+// AsUint returns a UINT attribute, such as a number of points (239, 233...).
+func (p *DeviceAttribute) AsUint() (uint64, error) {
+	if p.Type != AttributeUINT {
+		return 0, fmt.Errorf("%w: %s, not UINT", ErrAttributeType, p.Type)
+	}
+
+	if len(p.Value) == 0 || len(p.Value) > 8 {
+		return 0, fmt.Errorf("UINT attribute of %d bytes", len(p.Value))
+	}
+
+	return binary.LittleEndian.Uint64(paddedBytes(p.Value, 8)), nil
+}
+
+// AsInt returns an INT attribute, sign-extending it from its length.
+func (p *DeviceAttribute) AsInt() (int64, error) {
+	if p.Type != AttributeINT {
+		return 0, fmt.Errorf("%w: %s, not INT", ErrAttributeType, p.Type)
+	}
+
+	if len(p.Value) == 0 || len(p.Value) > 8 {
+		return 0, fmt.Errorf("INT attribute of %d bytes", len(p.Value))
+	}
+
+	shift := 64 - 8*len(p.Value)
+	unsigned := binary.LittleEndian.Uint64(paddedBytes(p.Value, 8))
+
+	return int64(unsigned<<shift) >> shift, nil //nolint:gosec // G115 - two's complement reinterpretation
+}
+
+// AsFloat returns a FLT attribute of 4 or 8 bytes.
+func (p *DeviceAttribute) AsFloat() (float64, error) {
+	if p.Type != AttributeFLT {
+		return 0, fmt.Errorf("%w: %s, not FLT", ErrAttributeType, p.Type)
+	}
+
+	switch len(p.Value) {
+	case 4:
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(p.Value))), nil
+	case 8:
+		return math.Float64frombits(binary.LittleEndian.Uint64(p.Value)), nil
+	default:
+		return 0, fmt.Errorf("FLT attribute of %d bytes", len(p.Value))
+	}
+}
+
+// SetString sets a VSTR value.
+func (p *DeviceAttribute) SetString(value string) error {
+	if len(value) > math.MaxUint8 {
+		return fmt.Errorf("device attribute string of %d bytes exceeds 255", len(value))
+	}
+
+	p.Type = AttributeVSTR
+	p.Value = []byte(value)
+
+	return nil
+}
+
+// SetUint sets a UINT value in the fewest of 1, 2, 4 or 8 bytes.
+func (p *DeviceAttribute) SetUint(value uint64) error {
+	p.Type = AttributeUINT
+	p.Value = binary.LittleEndian.AppendUint64(nil, value)[:integerWidth(value <= math.MaxUint8,
+		value <= math.MaxUint16, value <= math.MaxUint32)]
+
+	return nil
+}
+
+// SetInt sets an INT value in the fewest of 1, 2, 4 or 8 bytes.
+func (p *DeviceAttribute) SetInt(value int64) error {
+	p.Type = AttributeINT
+	p.Value = binary.LittleEndian.AppendUint64(nil, uint64(value))[:integerWidth( //nolint:gosec // G115 - two's complement
+		value >= math.MinInt8 && value <= math.MaxInt8,
+		value >= math.MinInt16 && value <= math.MaxInt16,
+		value >= math.MinInt32 && value <= math.MaxInt32)]
+
+	return nil
+}
+
+// SetFloat sets an 8-byte FLT value.
+func (p *DeviceAttribute) SetFloat(value float64) error {
+	p.Type = AttributeFLT
+	p.Value = binary.LittleEndian.AppendUint64(nil, math.Float64bits(value))
+
+	return nil
+}
+
+// integerWidth returns the fewest bytes an integer fits in, given whether it
+// fits in 1, 2 and 4.
+func integerWidth(fits1, fits2, fits4 bool) int {
+	switch {
+	case fits1:
+		return 1
+	case fits2:
+		return 2
+	case fits4:
+		return 4
+	default:
+		return 8
+	}
+}
+
+// AttributeVariation is an entry of the list of attribute variations
+// (variation 255): an attribute the outstation supports, and whether a
+// master may write it.
+type AttributeVariation struct {
+	Variation uint8 `json:"variation"`
+	Writable  bool  `json:"writable"`
+}
+
+// AttributeVariations returns a U8BS8LIST attribute, the value of the list of
+// attribute variations (255).
+func (p *DeviceAttribute) AttributeVariations() ([]AttributeVariation, error) {
+	if p.Type != AttributeU8BS8LIST {
+		return nil, fmt.Errorf("%w: %s, not U8BS8LIST", ErrAttributeType, p.Type)
+	}
+
+	if len(p.Value)%2 != 0 {
+		return nil, fmt.Errorf("U8BS8LIST attribute of odd length %d", len(p.Value))
+	}
+
+	list := make([]AttributeVariation, 0, len(p.Value)/2)
+
+	for i := 0; i < len(p.Value); i += 2 {
+		list = append(list, AttributeVariation{Variation: p.Value[i], Writable: p.Value[i+1]&0b00000001 != 0})
+	}
+
+	return list, nil
+}
+
+// SetAttributeVariations sets a U8BS8LIST value.
+func (p *DeviceAttribute) SetAttributeVariations(list []AttributeVariation) error {
+	if 2*len(list) > math.MaxUint8 {
+		return fmt.Errorf("%d attribute variations exceed 255 bytes", len(list))
+	}
+
+	value := make([]byte, 0, 2*len(list))
+
+	for _, entry := range list {
+		var properties byte
+		if entry.Writable {
+			properties = 0b00000001
+		}
+
+		value = append(value, entry.Variation, properties)
+	}
+
+	p.Type = AttributeU8BS8LIST
+	p.Value = value
+
+	return nil
+}
+
+// --- Constructor function ---
+
+func makeDeviceAttributeConstructor(variation uint8) PointsConstructor {
+	return func(data []byte, num, prefSize int, prefCode PointPrefixCode) ([]Point, int, error) {
+		if slices.Contains([]PointPrefixCode{Size1Octet, Size2Octet, Size4Octet, Reserved}, prefCode) {
+			return nil, 0, fmt.Errorf("device attributes can't use point prefix code %s", prefCode)
+		}
+
+		pointsOut := make([]Point, 0, num)
+		offset := 0
+
+		for range num {
+			point := &DeviceAttribute{Variation: variation}
+
+			err := point.DecodeFromBytes(data[offset:], prefSize)
+			if err != nil {
+				return pointsOut, offset, fmt.Errorf("could not decode device attribute: %w", err)
+			}
+
+			pointsOut = append(pointsOut, point)
+			offset += point.size()
+		}
+
+		return pointsOut, offset, nil
+	}
+}
@@ -7,8 +7,8 @@
 package dnp3
 
 import (
+	"encoding/json"
 	"fmt"
-	"math"
 
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
@@ -18,6 +18,16 @@ type Frame struct {
 	DataLink    DataLink    `json:"data_link"`
 	Transport   Transport   `json:"transport"`
 	Application Application `json:"application"`
+	// Segment holds the transport payload (transport header and CRCs
+	// removed) of a frame that carries only part of an application fragment,
+	// i.e. FIR and FIN are not both set. Application is nil in that case; use
+	// a TransportReassembler to rebuild the complete fragment. A lenient
+	// decode also keeps a complete fragment here if it is too short to
+	// decode.
+	Segment []byte `json:"segment,omitempty"`
+	// Warnings lists the anomalies a lenient DecodeFromBytesWithOptions let
+	// through.
+	Warnings []DecodeWarning `json:"warnings,omitempty"`
 
 	// contents caches the on-wire bytes captured during DecodeFromBytes so
 	// LayerContents can return them without re-encoding.
@@ -52,10 +62,24 @@ func NewFrameFromBytes(data []byte) (*Frame, error) {
 	return frame, nil
 }
 
+// NewFrameFromBytesWithOptions returns a new Frame parsed from the given bytes
+// with opts.
+func NewFrameFromBytesWithOptions(data []byte, opts DecodeOptions) (*Frame, error) {
+	frame := &Frame{}
+
+	err := frame.DecodeFromBytesWithOptions(data, gopacket.NilDecodeFeedback, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return frame, nil
+}
+
 // ParseFrames parses all complete DNP3 frames from data.
 // It returns the parsed frames, any unconsumed trailing bytes
 // (a partial frame), and the first error encountered.
-// On error, frames parsed before the error are also returned.
+// On error, frames parsed before the error are also returned. Use a Framer
+// to skip over bad input instead.
 func ParseFrames(data []byte) ([]*Frame, []byte, error) {
 	var frames []*Frame
 
@@ -178,6 +202,16 @@ func decodeDNP3(data []byte, builder gopacket.PacketBuilder) error {
 // gopacket.DecodingLayer. If data is shorter than the frame's declared wire
 // size, df.SetTruncated() is called before the error is returned.
 func (dnp *Frame) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	return dnp.DecodeFromBytesWithOptions(data, df, strictDecoding)
+}
+
+// DecodeFromBytesWithOptions is DecodeFromBytes with opts. Without
+// opts.Strict, only frames without the start bytes, or too short for their
+// length, fail to decode; other anomalies are listed in Warnings.
+func (dnp *Frame) DecodeFromBytesWithOptions(data []byte, df gopacket.DecodeFeedback, opts DecodeOptions) error {
+	dnp.Warnings = nil
+	opts.warnings = &dnp.Warnings
+
 	total, err := dnp.checkFrameBounds(data, df)
 	if err != nil {
 		return err
@@ -187,7 +221,7 @@ func (dnp *Frame) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error
 	// leak into LayerContents.
 	dnp.contents = append([]byte(nil), data[:total]...)
 
-	err = dnp.DataLink.DecodeFromBytes(data[:10])
+	err = dnp.DataLink.decodeWithOptions(data[:10], opts)
 	if err != nil {
 		return fmt.Errorf("error in DNP3 DataLink layer: %w", err)
 	}
@@ -197,7 +231,7 @@ func (dnp *Frame) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error
 		return nil
 	}
 
-	return dnp.decodeTransportAndApplication(data[10:total])
+	return dnp.decodeTransportAndApplication(data[10:total], opts)
 }
 
 // SerializeTo implements gopacket.SerializableLayer. It assembles the DNP3
@@ -207,6 +241,24 @@ func (dnp *Frame) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error
 // ignored. opts.FixLengths is honored implicitly since the length is always
 // recomputed from the current payload.
 func (dnp *Frame) SerializeTo(buf gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	if dnp.linkOnly() {
+		dnp.DataLink.Length = 5
+
+		dlBytes, err := dnp.DataLink.SerializeTo()
+		if err != nil {
+			return fmt.Errorf("error encoding data link: %w", err)
+		}
+
+		dst, err := buf.PrependBytes(len(dlBytes))
+		if err != nil {
+			return fmt.Errorf("prepending DNP3 bytes: %w", err)
+		}
+
+		copy(dst, dlBytes)
+
+		return nil
+	}
+
 	var transportApplication []byte
 
 	// get these first, for LEN in DL
@@ -216,22 +268,24 @@ func (dnp *Frame) SerializeTo(buf gopacket.SerializeBuffer, _ gopacket.Serialize
 	}
 
 	transportApplication = append(transportApplication, transportByte)
-	// Application isn't always set
-	if dnp.Application != nil {
-		applicationBytes, err := dnp.Application.SerializeTo()
-		if err != nil {
-			return fmt.Errorf("error encoding application data: %w", err)
-		}
 
-		transportApplication = append(transportApplication, applicationBytes...)
+	payload, err := dnp.transportPayload()
+	if err != nil {
+		return err
 	}
+
+	transportApplication = append(transportApplication, payload...)
 	// len is 5 more bytes in DL, excludes CRCs
 
 	payloadLength := len(transportApplication)
 	totalLength := payloadLength + 5
 
-	if totalLength > math.MaxUint16 {
-		return fmt.Errorf("transport/application payload too large: %d bytes", payloadLength)
+	if totalLength > 255 {
+		return fmt.Errorf(
+			"transport/application payload too large: %d bytes (a frame holds %d, use SegmentApplication)",
+			payloadLength,
+			MaxSegmentSize+1,
+		)
 	}
 
 	// #nosec G115 -- guarded by range check above
@@ -260,6 +314,8 @@ func (dnp *Frame) String() string {
 	appString := ""
 	if dnp.Application != nil {
 		appString = indent(dnp.Application.String(), "\t")
+	} else if len(dnp.Segment) > 0 {
+		appString = indent(fmt.Sprintf("Segment: 0x % X", dnp.Segment), "\t")
 	}
 
 	return fmt.Sprintf("DNP3:\n%s\n%s\n%s",
@@ -268,6 +324,81 @@ func (dnp *Frame) String() string {
 		appString)
 }
 
+// Application type discriminators used in Frame JSON.
+const (
+	applicationTypeRequest  = "request"
+	applicationTypeResponse = "response"
+)
+
+// MarshalJSON encodes the frame with an extra application_type field
+// ("request" or "response") so UnmarshalJSON can rebuild Application.
+func (dnp *Frame) MarshalJSON() ([]byte, error) {
+	type frameAlias Frame
+
+	applicationType := ""
+
+	switch dnp.Application.(type) {
+	case *ApplicationRequest:
+		applicationType = applicationTypeRequest
+	case *ApplicationResponse:
+		applicationType = applicationTypeResponse
+	}
+
+	jsonBytes, err := json.Marshal(struct {
+		*frameAlias
+
+		ApplicationType string `json:"application_type,omitempty"`
+	}{(*frameAlias)(dnp), applicationType})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal frame: %w", err)
+	}
+
+	return jsonBytes, nil
+}
+
+// UnmarshalJSON decodes a frame produced by MarshalJSON into a structure
+// SerializeTo can encode. Application is built from the application_type
+// field, falling back to the data link DIR bit as DecodeFromBytes does.
+func (dnp *Frame) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		DataLink        DataLink        `json:"data_link"`
+		Transport       Transport       `json:"transport"`
+		Application     json.RawMessage `json:"application"`
+		Segment         []byte          `json:"segment"`
+		Warnings        []DecodeWarning `json:"warnings"`
+		ApplicationType string          `json:"application_type"`
+	}
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal frame: %w", err)
+	}
+
+	*dnp = Frame{DataLink: raw.DataLink, Transport: raw.Transport, Segment: raw.Segment, Warnings: raw.Warnings}
+
+	if len(raw.Application) == 0 || string(raw.Application) == "null" {
+		return nil
+	}
+
+	switch raw.ApplicationType {
+	case applicationTypeRequest:
+		dnp.Application = &ApplicationRequest{}
+	case applicationTypeResponse:
+		dnp.Application = &ApplicationResponse{}
+	case "":
+		dnp.Application = newApplication(dnp.DataLink.Control.Direction)
+	default:
+		return fmt.Errorf("unknown application type %q", raw.ApplicationType)
+	}
+
+	err = json.Unmarshal(raw.Application, dnp.Application)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal application: %w", err)
+	}
+
+	return nil
+}
+
 // checkFrameBounds validates that data contains a complete DNP3 frame and
 // returns its total wire size. Calls df.SetTruncated() when data is short.
 func (*Frame) checkFrameBounds(data []byte, feedback gopacket.DecodeFeedback) (int, error) {
@@ -296,7 +427,7 @@ func (*Frame) checkFrameBounds(data []byte, feedback gopacket.DecodeFeedback) (i
 // decodeTransportAndApplication parses the post-header portion of a frame.
 // data must already be sliced to a single frame's wire bytes (excluding the
 // 10-byte DataLink header).
-func (dnp *Frame) decodeTransportAndApplication(data []byte) error {
+func (dnp *Frame) decodeTransportAndApplication(data []byte, opts DecodeOptions) error {
 	// Slice transport bytes to the payload boundary so a second frame in the
 	// same buffer cannot corrupt CRC validation.
 	payloadLen := int(dnp.DataLink.Length) - 5
@@ -308,7 +439,7 @@ func (dnp *Frame) decodeTransportAndApplication(data []byte) error {
 		transportData = transportData[:framePayloadBytes]
 	}
 
-	clean, err := dnp.Transport.DecodeFromBytes(transportData)
+	clean, err := dnp.Transport.decodeWithOptions(transportData, opts)
 	if err != nil {
 		return fmt.Errorf("error in DNP3 Transport layer: %w", err)
 	}
@@ -317,16 +448,74 @@ func (dnp *Frame) decodeTransportAndApplication(data []byte) error {
 		return nil
 	}
 
-	if dnp.DataLink.Control.Direction {
-		dnp.Application = &ApplicationRequest{}
-	} else {
-		dnp.Application = &ApplicationResponse{}
+	// Only a FIR+FIN segment holds a complete application fragment; anything
+	// else is kept raw for a TransportReassembler.
+	if !dnp.Transport.First || !dnp.Transport.Final {
+		dnp.Segment = clean
+
+		return nil
+	}
+
+	switch app := newApplication(dnp.DataLink.Control.Direction).(type) {
+	case *ApplicationRequest:
+		dnp.Application = app
+		err = app.decodeWithOptions(clean, opts)
+	case *ApplicationResponse:
+		dnp.Application = app
+		err = app.decodeWithOptions(clean, opts)
 	}
 
-	err = dnp.Application.DecodeFromBytes(clean)
 	if err != nil {
-		return fmt.Errorf("error in DNP3 Application layer: %w", err)
+		err = opts.tolerate("application", clean, fmt.Errorf("error in DNP3 Application layer: %w", err))
+		if err != nil {
+			return err
+		}
+
+		// Keep the fragment raw, so the frame still encodes to the same
+		// bytes.
+		dnp.Application = nil
+		dnp.Segment = clean
 	}
 
 	return nil
 }
+
+// linkOnly reports whether the frame is a bare data link header: a link
+// service (reset, test, status, acknowledgement) with nothing to carry.
+func (dnp *Frame) linkOnly() bool {
+	if dnp.Application != nil || len(dnp.Segment) > 0 || dnp.DataLink.Control.FunctionCode == nil {
+		return false
+	}
+
+	code, ok := dnp.DataLink.Control.FunctionCode.(DataLinkPrimaryFunctionCode)
+
+	return !ok || (code != ConfirmedUserData && code != UnconfirmedUserData)
+}
+
+// transportPayload returns the bytes carried after the transport header:
+// the encoded Application if set, otherwise the raw Segment.
+func (dnp *Frame) transportPayload() ([]byte, error) {
+	if dnp.Application == nil {
+		return dnp.Segment, nil
+	}
+
+	applicationBytes, err := dnp.Application.SerializeTo()
+	if err != nil {
+		return nil, fmt.Errorf("error encoding application data: %w", err)
+	}
+
+	return applicationBytes, nil
+}
+
+// newApplication returns an empty Application of the type implied by the
+// data link DIR bit: requests flow from the master, responses from the
+// outstation.
+//
+//nolint:ireturn // callers decode into whichever concrete type DIR selects.
+func newApplication(direction bool) Application {
+	if direction {
+		return &ApplicationRequest{}
+	}
+
+	return &ApplicationResponse{}
+}
@@ -1,13 +1,19 @@
 package dnp3_test
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
@@ -540,3 +546,2098 @@ func TestSerializeLayers(t *testing.T) {
 		})
 	}
 }
+
+// analogResponse returns the application bytes of a solicited response
+// carrying num 32-bit analog inputs with flags (g30v1), indexes 0..num-1.
+func analogResponse(num int) []byte {
+	app := []byte{0xc0, 0x81, 0x00, 0x00, 0x1e, 0x01, 0x01}
+	app = append(app, 0x00, 0x00, byte(num-1), byte((num-1)>>8))
+
+	for i := range num {
+		app = append(app, 0x01, byte(i), byte(i>>8), 0x00, 0x00)
+	}
+
+	return app
+}
+
+// TestTransportReassembler splits a large response across hand-built
+// segment frames, decodes them from the wire and reassembles the fragment.
+func TestTransportReassembler(t *testing.T) {
+	t.Parallel()
+
+	app := analogResponse(120)
+	reassembler := dnp3.NewTransportReassembler()
+
+	var result dnp3.Application
+
+	for offset, seq := 0, uint8(60); offset < len(app); offset, seq = offset+249, (seq+1)%64 {
+		end := min(offset+249, len(app))
+		frame := dnp3.NewFrame()
+		frame.DataLink.Source = 4
+		frame.DataLink.Destination = 3
+		frame.DataLink.Control.Primary = true
+		frame.DataLink.Control.FunctionCode = dnp3.UnconfirmedUserData
+		frame.Transport = dnp3.Transport{First: offset == 0, Final: end == len(app), Sequence: seq}
+		frame.Segment = app[offset:end]
+
+		decoded, err := dnp3.NewFrameFromBytes(serializeFrame(t, frame))
+		if err != nil {
+			t.Fatal("NewFrameFromBytes:", err)
+		}
+
+		if decoded.Application != nil {
+			t.Fatal("partial segment should not decode an application")
+		}
+
+		result, err = reassembler.Push(decoded)
+		if err != nil {
+			t.Fatal("Push:", err)
+		}
+	}
+
+	if result == nil {
+		t.Fatal("expected a reassembled application")
+	}
+
+	if reassembler.Pending() != 0 {
+		t.Fatalf("expected no pending fragments, got %d", reassembler.Pending())
+	}
+
+	out, err := result.SerializeTo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(out, app) {
+		t.Fatalf("reassembled fragment mismatch\nwant: %x\n got: %x", app, out)
+	}
+}
+
+func TestTransportReassembler_gap(t *testing.T) {
+	t.Parallel()
+
+	reassembler := dnp3.NewTransportReassembler()
+	first := &dnp3.Frame{
+		Transport: dnp3.Transport{First: true, Sequence: 5},
+		Segment:   []byte{0xc0, 0x81},
+	}
+	middle := &dnp3.Frame{
+		Transport: dnp3.Transport{Sequence: 6},
+		Segment:   []byte{0x00},
+	}
+	last := &dnp3.Frame{
+		Transport: dnp3.Transport{Final: true, Sequence: 7},
+		Segment:   []byte{0x00},
+	}
+	skipped := &dnp3.Frame{
+		Transport: dnp3.Transport{Final: true, Sequence: 7},
+		Segment:   []byte{0x00, 0x00},
+	}
+
+	// A repeated segment, as after a data link retry, is ignored.
+	for _, frame := range []*dnp3.Frame{first, middle, middle} {
+		_, err := reassembler.Push(frame)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	app, err := reassembler.Push(last)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := app.SerializeTo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(out, []byte{0xc0, 0x81, 0x00, 0x00}) {
+		t.Fatalf("expected C0 81 00 00, got % X", out)
+	}
+
+	app, err = reassembler.Push(last)
+	if app != nil || err != nil {
+		t.Fatalf("expected a repeated FIN to be ignored, got %v, %v", app, err)
+	}
+
+	_, err = reassembler.Push(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = reassembler.Push(skipped)
+	if !errors.Is(err, dnp3.ErrTransportSequence) {
+		t.Fatalf("expected ErrTransportSequence, got %v", err)
+	}
+
+	_, err = reassembler.Push(skipped)
+	if !errors.Is(err, dnp3.ErrTransportNoFirst) {
+		t.Fatalf("expected ErrTransportNoFirst, got %v", err)
+	}
+}
+
+// TestSegmentApplication splits a large response into frames and feeds the
+// wire bytes back through ParseFrames and a TransportReassembler.
+func TestSegmentApplication(t *testing.T) {
+	t.Parallel()
+
+	app := analogResponse(200)
+
+	response, err := dnp3.NewApplicationResponseFromBytes(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dataLink dnp3.DataLink
+	dataLink.Source = 4
+	dataLink.Destination = 3
+	dataLink.Control.Primary = true
+	dataLink.Control.FunctionCode = dnp3.UnconfirmedUserData
+
+	frames, err := dnp3.SegmentApplication(dataLink, response, 62)
+	if err != nil {
+		t.Fatal("SegmentApplication:", err)
+	}
+
+	if len(frames) != 5 {
+		t.Fatalf("expected 5 frames, got %d", len(frames))
+	}
+
+	var wire []byte
+
+	for i, frame := range frames {
+		if frame.DataLink.Length > 255 {
+			t.Fatalf("frame %d length %d exceeds 255", i, frame.DataLink.Length)
+		}
+
+		if frame.Transport.First != (i == 0) || frame.Transport.Final != (i == len(frames)-1) {
+			t.Fatalf("frame %d has FIR=%t FIN=%t", i, frame.Transport.First, frame.Transport.Final)
+		}
+
+		if want := uint8((62 + i) % 64); frame.Transport.Sequence != want {
+			t.Fatalf("frame %d sequence %d, want %d", i, frame.Transport.Sequence, want)
+		}
+
+		wire = append(wire, serializeFrame(t, frame)...)
+	}
+
+	decoded, remainder, err := dnp3.ParseFrames(wire)
+	if err != nil || len(remainder) != 0 {
+		t.Fatalf("ParseFrames: %v (remainder %d bytes)", err, len(remainder))
+	}
+
+	reassembler := dnp3.NewTransportReassembler()
+
+	var result dnp3.Application
+
+	for _, frame := range decoded {
+		result, err = reassembler.Push(frame)
+		if err != nil {
+			t.Fatal("Push:", err)
+		}
+	}
+
+	if result == nil {
+		t.Fatal("expected a reassembled application")
+	}
+
+	out, err := result.SerializeTo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(out, app) {
+		t.Fatal("reassembled fragment does not match the segmented application")
+	}
+}
+
+// TestPointBytes_layouts decodes one point of each variation whose layout
+// was corrected: events that carry flags, and values that precede their
+// timestamp.
+func TestPointBytes_layouts(t *testing.T) {
+	t.Parallel()
+
+	// One point at index 5 (qualifier 0x17), online, value 0x2A, and where
+	// present a timestamp of 1000 ms.
+	value16 := []byte{0x2a, 0x00}
+	value32 := []byte{0x2a, 0x00, 0x00, 0x00}
+	value64 := []byte{0x2a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	timestamp := []byte{0xe8, 0x03, 0x00, 0x00, 0x00, 0x00}
+
+	cases := []struct {
+		group, variation uint8
+		value            []byte
+		timed            bool
+	}{
+		{21, 5, value32, true},
+		{23, 2, value16, false},
+		{31, 3, value32, true},
+		{31, 4, value16, true},
+		{32, 1, value32, false},
+		{32, 3, value32, true},
+		{33, 6, value64, false},
+		{33, 8, value64, true},
+		{42, 2, value16, false},
+		{42, 7, value32, true},
+	}
+
+	for _, tc := range cases {
+		data := append([]byte{tc.group, tc.variation, 0x17, 0x01, 0x05, 0x01}, tc.value...)
+		if tc.timed {
+			data = append(data, timestamp...)
+		}
+
+		object, err := dnp3.NewDataObjectFromBytes(data)
+		if err != nil {
+			t.Fatalf("g%dv%d: %v", tc.group, tc.variation, err)
+		}
+
+		point, ok := object.Points[0].(*dnp3.PointBytes)
+		if !ok {
+			t.Fatalf("g%dv%d: unexpected point type %T", tc.group, tc.variation, object.Points[0])
+		}
+
+		if point.Flags == nil || point.Flags.ToByte() != 0x01 {
+			t.Fatalf("g%dv%d: flags %v, want online", tc.group, tc.variation, point.Flags)
+		}
+
+		if !slices.Equal(point.Value, tc.value) {
+			t.Fatalf("g%dv%d: value 0x % X, want 0x % X", tc.group, tc.variation, point.Value, tc.value)
+		}
+
+		if tc.timed != (point.AbsoluteTime != nil) {
+			t.Fatalf("g%dv%d: absolute time %v, want timed=%t", tc.group, tc.variation, point.AbsoluteTime, tc.timed)
+		}
+
+		if tc.timed && time.Time(*point.AbsoluteTime).UnixMilli() != 1000 {
+			t.Fatalf("g%dv%d: absolute time %v, want 1000ms", tc.group, tc.variation, point.AbsoluteTime)
+		}
+
+		out, err := object.SerializeTo()
+		if err != nil || !slices.Equal(out, data) {
+			t.Fatalf("g%dv%d: serialized 0x % X (%v), want 0x % X", tc.group, tc.variation, out, err, data)
+		}
+	}
+}
+
+func TestPointBytes_numeric(t *testing.T) {
+	t.Parallel()
+
+	response, err := dnp3.NewApplicationResponseFromBytes(analogResponse(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	point, ok := response.Data.Objects[0].Points[2].(*dnp3.PointBytes)
+	if !ok {
+		t.Fatalf("unexpected point type %T", response.Data.Objects[0].Points[2])
+	}
+
+	if point.NumericEncoding() != dnp3.NumericInt32 {
+		t.Fatalf("encoding = %s, want NumericInt32", point.NumericEncoding())
+	}
+
+	value, err := point.AsInt64()
+	if err != nil || value != 2 {
+		t.Fatalf("AsInt64 = %d, %v; want 2", value, err)
+	}
+
+	err = point.SetNumeric(-70000)
+	if err != nil {
+		t.Fatal("SetNumeric:", err)
+	}
+
+	if !slices.Equal(point.Value, []byte{0x90, 0xEE, 0xFE, 0xFF}) {
+		t.Fatalf("encoded value %x", point.Value)
+	}
+
+	floatValue, err := point.AsFloat64()
+	if err != nil || floatValue != -70000 {
+		t.Fatalf("AsFloat64 = %v, %v; want -70000", floatValue, err)
+	}
+
+	for _, bad := range []float64{1.5, 1 << 31, math.NaN()} {
+		if point.SetNumeric(bad) == nil {
+			t.Fatalf("SetNumeric(%v) should fail for int32", bad)
+		}
+	}
+}
+
+// TestPointBytes_eventLayout checks that analog events with time split into
+// flags, a numeric value and an absolute timestamp.
+func TestPointBytes_eventLayout(t *testing.T) {
+	t.Parallel()
+
+	// g32v7: single-precision analog event with time, one point, index 4.
+	data := []byte{
+		0x20, 0x07, 0x17, 0x01, 0x04,
+		0x01, 0x00, 0x00, 0xc0, 0x3f,
+		0xe8, 0x03, 0x00, 0x00, 0x00, 0x00,
+	}
+
+	object, err := dnp3.NewDataObjectFromBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	point, ok := object.Points[0].(*dnp3.PointBytes)
+	if !ok {
+		t.Fatalf("unexpected point type %T", object.Points[0])
+	}
+
+	flags, err := point.GetFlags()
+	if err != nil || !flags.Online {
+		t.Fatalf("flags = %+v, %v; want online", flags, err)
+	}
+
+	value, err := point.AsFloat64()
+	if err != nil || value != 1.5 {
+		t.Fatalf("AsFloat64 = %v, %v; want 1.5", value, err)
+	}
+
+	absTime, err := point.GetAbsTime()
+	if err != nil || absTime.Time().UnixMilli() != 1000 {
+		t.Fatalf("GetAbsTime = %v, %v; want 1000ms", absTime.Time(), err)
+	}
+}
+
+func TestCROB(t *testing.T) {
+	t.Parallel()
+
+	frame, err := dnp3.NewFrameFromBytes(tests[3].input) // Request/Select
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crob, ok := frame.Application.GetData().Objects[0].Points[0].(*dnp3.CROB)
+	if !ok {
+		t.Fatalf("unexpected point type %T", frame.Application.GetData().Objects[0].Points[0])
+	}
+
+	if crob.OpType != dnp3.OpTypeLatchOn || crob.Count != 1 || crob.OnTime != 100 ||
+		crob.OffTime != 100 || crob.Status != dnp3.CommandStatusSuccess {
+		t.Fatalf("unexpected CROB %+v", crob)
+	}
+
+	crob.OpType = dnp3.OpTypePulseOn
+	crob.TripCloseCode = dnp3.TCCTrip
+	crob.Queue = true
+
+	encoded, err := crob.SerializeTo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if encoded[2] != 0b10010001 {
+		t.Fatalf("control code = %08b, want 10010001", encoded[2])
+	}
+
+	jsonBytes, err := json.Marshal(crob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(jsonBytes), `"op_type":1`) ||
+		!strings.Contains(string(jsonBytes), `"trip_close_code":2`) {
+		t.Fatalf("unexpected JSON %s", jsonBytes)
+	}
+
+	// The reserved status bit decodes as received, but can't be sent.
+	data := append(slices.Clone(encoded[:12]), 0x80)
+
+	var reserved dnp3.CROB
+
+	err = reserved.DecodeFromBytes(data, 2)
+	if err != nil || reserved.Status != 0x80 {
+		t.Fatalf("decoded status %d (%v), want 128", reserved.Status, err)
+	}
+
+	_, err = reserved.SerializeTo()
+	if err == nil {
+		t.Fatal("expected an error serializing status 128")
+	}
+
+	err = crob.SetValue(reserved)
+	if err == nil {
+		t.Fatal("expected an error setting status 128")
+	}
+}
+
+// TestPatternMask round-trips a pattern control request: a g12v2 PCB
+// followed by the g12v3 mask selecting which outputs it applies to.
+func TestPatternMask(t *testing.T) {
+	t.Parallel()
+
+	data := []byte{
+		0x0c, 0x02, 0x07, 0x01, // g12v2, count 1
+		0x41, 0x01, 0xf4, 0x01, 0x00, 0x00, 0xf4, 0x01, 0x00, 0x00, 0x00,
+		0x0c, 0x03, 0x00, 0x02, 0x0b, // g12v3, start 2 stop 11
+		0b10100101, 0b00000011,
+	}
+
+	appData, err := dnp3.NewApplicationDataFromBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(appData.Objects) != 2 || appData.HasExtra() {
+		t.Fatalf("expected 2 objects and no extra, got %d objects", len(appData.Objects))
+	}
+
+	mask := appData.Objects[1]
+	if len(mask.Points) != 10 {
+		t.Fatalf("expected 10 mask points, got %d", len(mask.Points))
+	}
+
+	want := []bool{true, false, true, false, false, true, false, true, true, true}
+	for i, point := range mask.Points {
+		if point.GetValue() != want[i] {
+			t.Fatalf("mask bit %d (index %d) = %v, want %v",
+				i, mask.Indexes()[i], point.GetValue(), want[i])
+		}
+	}
+
+	out, err := appData.SerializeTo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(out, data) {
+		t.Fatalf("round-trip mismatch\nwant: %x\n got: %x", data, out)
+	}
+}
+
+// TestFrameJSONRoundTrip checks that every test vector survives
+// json.Marshal/json.Unmarshal and still serializes to the original bytes.
+func TestFrameJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			frame, err := dnp3.NewFrameFromBytes(testCase.input)
+			if err != nil {
+				t.Fatal("NewFrameFromBytes:", err)
+			}
+
+			jsonBytes, err := json.Marshal(frame)
+			if err != nil {
+				t.Fatal("Marshal:", err)
+			}
+
+			var decoded dnp3.Frame
+
+			err = json.Unmarshal(jsonBytes, &decoded)
+			if err != nil {
+				t.Fatal("Unmarshal:", err)
+			}
+
+			got := serializeFrame(t, &decoded)
+			if !slices.Equal(got, testCase.input) {
+				t.Fatalf("JSON round-trip mismatch\ngot:  %x\nwant: %x", got, testCase.input)
+			}
+		})
+	}
+}
+
+// TestApplicationRequest_readHeaders round-trips a read request with
+// start-stop, indexed and class headers.
+func TestApplicationRequest_readHeaders(t *testing.T) {
+	t.Parallel()
+
+	data := []byte{
+		0xc3, 0x01, // read
+		0x1e, 0x01, 0x00, 0x00, 0x03, // g30v1, start 0 stop 3
+		0x01, 0x02, 0x17, 0x02, 0x05, 0x09, // g1v2, count 2, indexes 5 and 9
+		0x3c, 0x01, 0x06, // class 0
+	}
+
+	req, err := dnp3.NewApplicationRequestFromBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(req.Data.Objects) != 3 || req.Data.HasExtra() {
+		t.Fatalf("expected 3 objects and no extra, got %d objects", len(req.Data.Objects))
+	}
+
+	if indexes := req.Data.Objects[1].Indexes(); !slices.Equal(indexes, []int{5, 9}) {
+		t.Fatalf("expected indexes [5 9], got %v", indexes)
+	}
+
+	out, err := req.SerializeTo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(out, data) {
+		t.Fatalf("round-trip mismatch\nwant: %x\n got: %x", data, out)
+	}
+}
+
+// writerFunc adapts a function to io.Writer.
+type writerFunc func([]byte) (int, error)
+
+func (f writerFunc) Write(data []byte) (int, error) {
+	return f(data)
+}
+
+// linkPair connects a confirmed primary LinkLayer to a secondary one in
+// memory. Frames for which drop returns true are lost on the way; the
+// segments the secondary delivers are collected in order.
+type linkPair struct {
+	primary, secondary *dnp3.LinkLayer
+	delivered          []byte
+	drop               func(*dnp3.Frame) bool
+}
+
+func newLinkPair(retries int) *linkPair {
+	pair := &linkPair{drop: func(*dnp3.Frame) bool { return false }}
+
+	forward := func(to **dnp3.LinkLayer) writerFunc {
+		return func(data []byte) (int, error) {
+			frames, _, err := dnp3.ParseFrames(data)
+			if err != nil {
+				return 0, err
+			}
+
+			for _, frame := range frames {
+				if pair.drop(frame) {
+					continue
+				}
+
+				deliver, err := (*to).Receive(frame)
+				if err != nil {
+					return 0, err
+				}
+
+				if deliver {
+					pair.delivered = append(pair.delivered, frame.Segment...)
+				}
+			}
+
+			return len(data), nil
+		}
+	}
+
+	pair.primary = dnp3.NewLinkLayer(forward(&pair.secondary), dnp3.LinkConfig{
+		LocalAddress:  1,
+		RemoteAddress: 10,
+		Master:        true,
+		Confirmed:     true,
+		Retries:       retries,
+		Timeout:       20 * time.Millisecond,
+	})
+	pair.secondary = dnp3.NewLinkLayer(forward(&pair.primary), dnp3.LinkConfig{
+		LocalAddress:  10,
+		RemoteAddress: 1,
+	})
+
+	return pair
+}
+
+// send sends a one-byte transport segment through the primary station.
+func (pair *linkPair) send(t *testing.T, value byte) error {
+	t.Helper()
+
+	frame := dnp3.NewFrame()
+	frame.Transport = dnp3.Transport{First: true, Sequence: value}
+	frame.Segment = []byte{value}
+
+	return pair.primary.Send(t.Context(), frame)
+}
+
+func TestLinkLayer_confirmed(t *testing.T) {
+	t.Parallel()
+
+	pair := newLinkPair(2)
+
+	var codes []string
+
+	pair.drop = func(frame *dnp3.Frame) bool {
+		control := frame.DataLink.Control
+		codes = append(codes, fmt.Sprintf("%s FCB=%t", control.FunctionCode, control.FrameCountBit))
+
+		return false
+	}
+
+	for value := range byte(3) {
+		err := pair.send(t, value)
+		if err != nil {
+			t.Fatal("Send:", err)
+		}
+	}
+
+	want := []string{
+		"ResetLinkStates FCB=false", "Ack FCB=false",
+		"ConfirmedUserData FCB=true", "Ack FCB=false",
+		"ConfirmedUserData FCB=false", "Ack FCB=false",
+		"ConfirmedUserData FCB=true", "Ack FCB=false",
+	}
+	if !slices.Equal(codes, want) {
+		t.Fatalf("unexpected exchange\nwant: %q\n got: %q", want, codes)
+	}
+
+	if !slices.Equal(pair.delivered, []byte{0, 1, 2}) {
+		t.Fatalf("delivered %v, want [0 1 2]", pair.delivered)
+	}
+}
+
+// TestLinkLayer_lostAck loses the Ack for a confirmed frame: the primary
+// retransmits it with the same FCB and the secondary acknowledges the
+// duplicate without delivering it twice.
+func TestLinkLayer_lostAck(t *testing.T) {
+	t.Parallel()
+
+	pair := newLinkPair(2)
+
+	err := pair.send(t, 1)
+	if err != nil {
+		t.Fatal("Send:", err)
+	}
+
+	lost := false
+	pair.drop = func(frame *dnp3.Frame) bool {
+		if !lost && frame.DataLink.Control.FunctionCode == dnp3.Ack {
+			lost = true
+
+			return true
+		}
+
+		return false
+	}
+
+	err = pair.send(t, 2)
+	if err != nil {
+		t.Fatal("Send after a lost Ack:", err)
+	}
+
+	err = pair.send(t, 3)
+	if err != nil {
+		t.Fatal("Send:", err)
+	}
+
+	if !lost {
+		t.Fatal("no Ack was dropped")
+	}
+
+	if !slices.Equal(pair.delivered, []byte{1, 2, 3}) {
+		t.Fatalf("delivered %v, want [1 2 3]", pair.delivered)
+	}
+}
+
+// TestLinkLayer_timeout drops every frame: the primary gives up after its
+// retries, and resets the link again once the secondary is reachable.
+func TestLinkLayer_timeout(t *testing.T) {
+	t.Parallel()
+
+	pair := newLinkPair(1)
+
+	sent := 0
+	pair.drop = func(*dnp3.Frame) bool {
+		sent++
+
+		return true
+	}
+
+	err := pair.send(t, 1)
+	if !errors.Is(err, dnp3.ErrLinkTimeout) {
+		t.Fatalf("expected ErrLinkTimeout, got %v", err)
+	}
+
+	if sent != 2 {
+		t.Fatalf("expected 2 attempts, got %d", sent)
+	}
+
+	pair.drop = func(*dnp3.Frame) bool { return false }
+
+	err = pair.primary.RequestLinkStatus(t.Context())
+	if err != nil {
+		t.Fatal("RequestLinkStatus:", err)
+	}
+
+	err = pair.send(t, 2)
+	if err != nil {
+		t.Fatal("Send:", err)
+	}
+
+	if !slices.Equal(pair.delivered, []byte{2}) {
+		t.Fatalf("delivered %v, want [2]", pair.delivered)
+	}
+}
+
+// TestLinkLayer_notReset sends confirmed user data to a secondary station
+// whose link was never reset: it answers Nack and delivers nothing.
+func TestLinkLayer_notReset(t *testing.T) {
+	t.Parallel()
+
+	var replies []dnp3.DataLinkFunction
+
+	secondary := dnp3.NewLinkLayer(writerFunc(func(data []byte) (int, error) {
+		frames, _, err := dnp3.ParseFrames(data)
+		for _, frame := range frames {
+			replies = append(replies, frame.DataLink.Control.FunctionCode)
+		}
+
+		return len(data), err
+	}), dnp3.LinkConfig{LocalAddress: 10})
+
+	frame := dnp3.NewFrame()
+	frame.DataLink.Destination = 10
+	frame.DataLink.Control = dnp3.DataLinkControl{
+		Direction:       true,
+		Primary:         true,
+		FrameCountBit:   true,
+		FrameCountValid: true,
+		FunctionCode:    dnp3.ConfirmedUserData,
+	}
+
+	deliver, err := secondary.Receive(frame)
+	if err != nil {
+		t.Fatal("Receive:", err)
+	}
+
+	if deliver || len(replies) != 1 || replies[0] != dnp3.Nack {
+		t.Fatalf("expected a Nack and no delivery, got deliver=%t replies=%v", deliver, replies)
+	}
+}
+
+// TestLinkLayer_otherStation answers a ResetLinkStates with Acks from another
+// station and to another station: neither completes it.
+func TestLinkLayer_otherStation(t *testing.T) {
+	t.Parallel()
+
+	var primary *dnp3.LinkLayer
+
+	primary = dnp3.NewLinkLayer(writerFunc(func(data []byte) (int, error) {
+		for _, addresses := range [][2]uint16{{11, 1}, {10, 2}} {
+			ack := dnp3.NewFrame()
+			ack.DataLink.Source = addresses[0]
+			ack.DataLink.Destination = addresses[1]
+			ack.DataLink.Control = dnp3.DataLinkControl{FunctionCode: dnp3.Ack}
+
+			_, err := primary.Receive(ack)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		return len(data), nil
+	}), dnp3.LinkConfig{
+		LocalAddress:  1,
+		RemoteAddress: 10,
+		Master:        true,
+		Confirmed:     true,
+		Timeout:       20 * time.Millisecond,
+	})
+
+	err := primary.ResetLink(t.Context())
+	if !errors.Is(err, dnp3.ErrLinkTimeout) {
+		t.Fatalf("expected ErrLinkTimeout, got %v", err)
+	}
+}
+
+// noisyStream surrounds two valid frames with noise, a frame with a corrupted
+// data block and a false start sequence.
+func noisyStream() []byte {
+	corrupted := slices.Clone(readBinaryInputChange)
+	corrupted[12] ^= 0xff
+
+	var stream []byte
+	stream = append(stream, 0x00, 0x05, 0x13, 0x64)
+	stream = append(stream, readBinaryInputChange...)
+	stream = append(stream, corrupted...)
+	stream = append(stream, 0x05, 0x64, 0xff)
+	stream = append(stream, readClass1230...)
+
+	return stream
+}
+
+func TestFramer_push(t *testing.T) {
+	t.Parallel()
+
+	framer := dnp3.NewFramer(nil)
+
+	var frames []*dnp3.Frame
+
+	// One byte at a time, as a serial port might deliver them.
+	for _, b := range noisyStream() {
+		frames = append(frames, framer.Push([]byte{b})...)
+	}
+
+	if len(frames) != 2 {
+		t.Fatalf("expected 2 frames, got %d", len(frames))
+	}
+
+	want := dnp3.FramerStats{
+		Frames:         2,
+		DiscardedBytes: 4 + len(readBinaryInputChange) + 3,
+		CRCErrors:      2,
+	}
+	if got := framer.Stats(); got != want {
+		t.Fatalf("unexpected stats\nwant: %+v\n got: %+v", want, got)
+	}
+
+	if framer.Buffered() != 0 {
+		t.Fatalf("expected nothing buffered, got %d bytes", framer.Buffered())
+	}
+}
+
+func TestFramer_next(t *testing.T) {
+	t.Parallel()
+
+	stream := noisyStream()
+	framer := dnp3.NewFramer(bytes.NewReader(append(stream, 0x05)))
+
+	for i := range 2 {
+		frame, err := framer.Next()
+		if err != nil {
+			t.Fatalf("frame %d: %v", i, err)
+		}
+
+		if frame.Application == nil {
+			t.Fatalf("frame %d has no application layer", i)
+		}
+	}
+
+	_, err := framer.Next()
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+
+	// The trailing 0x05 may still start a frame.
+	if framer.Buffered() != 1 {
+		t.Fatalf("expected 1 byte buffered, got %d", framer.Buffered())
+	}
+}
+
+// ctoObject returns a Group 51 object of variation holding at.
+func ctoObject(t *testing.T, variation uint8, at time.Time) dnp3.DataObject {
+	t.Helper()
+
+	points, err := dnp3.NewPoints(51, variation, dnp3.NoPrefix, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = points[0].SetAbsTime(dnp3.AbsoluteTime(at))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rangeField := dnp3.NewCountRangeField(1)
+
+	return dnp3.DataObject{
+		Header: dnp3.ObjectHeader{Group: 51, Variation: variation, RangeSpecCode: rangeField.Code(), RangeField: rangeField},
+		Points: points,
+	}
+}
+
+// relativeEvents returns a group/variation 3 event object with one
+// index-prefixed point per offset.
+func relativeEvents(t *testing.T, group uint8, offsets ...time.Duration) dnp3.DataObject {
+	t.Helper()
+
+	points, err := dnp3.NewPoints(group, 3, dnp3.Index1Octet, len(offsets))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, offset := range offsets {
+		err = points[i].SetIndex(i)
+		if err == nil {
+			err = points[i].SetRelTime(dnp3.RelativeTime(offset))
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rangeField := dnp3.NewCountRangeField(uint32(len(offsets)))
+
+	return dnp3.DataObject{
+		Header: dnp3.ObjectHeader{
+			Group:           group,
+			Variation:       3,
+			PointPrefixCode: dnp3.Index1Octet,
+			RangeSpecCode:   rangeField.Code(),
+			RangeField:      rangeField,
+		},
+		Points: points,
+	}
+}
+
+func TestApplicationData_ResolveCTO(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	built := dnp3.ApplicationData{Objects: []dnp3.DataObject{
+		ctoObject(t, 1, base),
+		relativeEvents(t, 2, 0, 250*time.Millisecond),
+		ctoObject(t, 2, base.Add(time.Hour)),
+		relativeEvents(t, 4, 1500*time.Millisecond),
+	}}
+
+	encoded, err := built.SerializeTo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := dnp3.NewApplicationDataFromBytes(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = data.ResolveCTO()
+	if err != nil {
+		t.Fatal("ResolveCTO:", err)
+	}
+
+	for _, want := range []struct {
+		object, point int
+		time          time.Time
+		synchronized  bool
+	}{
+		{1, 0, base, true},
+		{1, 1, base.Add(250 * time.Millisecond), true},
+		{3, 0, base.Add(time.Hour + 1500*time.Millisecond), false},
+	} {
+		point, ok := data.Objects[want.object].Points[want.point].(*dnp3.PointBytes)
+		if !ok || point.ResolvedTime == nil {
+			t.Fatalf("object %d point %d not resolved", want.object, want.point)
+		}
+
+		if got := point.ResolvedTime.Time.Time(); !got.Equal(want.time) ||
+			point.ResolvedTime.Synchronized != want.synchronized {
+			t.Fatalf("object %d point %d: expected %s (synchronized %t), got %s",
+				want.object, want.point, want.time, want.synchronized, point.ResolvedTime)
+		}
+	}
+
+	orphan := dnp3.ApplicationData{Objects: []dnp3.DataObject{relativeEvents(t, 2, time.Second)}}
+	err = orphan.ResolveCTO()
+	if !errors.Is(err, dnp3.ErrNoCTO) {
+		t.Fatalf("expected ErrNoCTO, got %v", err)
+	}
+}
+
+// attributeObject returns a Group 0 object holding one attribute of set 0.
+func attributeObject(t *testing.T, variation uint8, value any) dnp3.DataObject {
+	t.Helper()
+
+	points, err := dnp3.NewPoints(0, variation, dnp3.NoPrefix, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = points[0].SetValue(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rangeField := dnp3.NewStartStopRangeField(0, 0)
+
+	return dnp3.DataObject{
+		Header: dnp3.ObjectHeader{Group: 0, Variation: variation, RangeSpecCode: rangeField.Code(), RangeField: rangeField},
+		Points: points,
+	}
+}
+
+func TestDeviceAttribute(t *testing.T) {
+	t.Parallel()
+
+	list := []dnp3.AttributeVariation{{Variation: 242}, {Variation: 246, Writable: true}}
+	built := dnp3.ApplicationData{Objects: []dnp3.DataObject{
+		attributeObject(t, 242, "1.2.3"),
+		attributeObject(t, 239, uint16(300)),
+		attributeObject(t, 217, int64(-2)),
+		attributeObject(t, 218, 0.5),
+		attributeObject(t, 255, list),
+	}}
+
+	encoded, err := built.SerializeTo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// g0v242, start-stop 0-0, VSTR of 5 bytes.
+	if want := []byte{0x00, 0xF2, 0x00, 0x00, 0x00, 0x01, 0x05, '1', '.', '2', '.', '3'}; !bytes.HasPrefix(encoded, want) {
+		t.Fatalf("expected encoding to start with % X, got % X", want, encoded)
+	}
+
+	data, err := dnp3.NewApplicationDataFromBytes(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attributes := make([]*dnp3.DeviceAttribute, 0, len(data.Objects))
+
+	for _, object := range data.Objects {
+		attribute, ok := object.Points[0].(*dnp3.DeviceAttribute)
+		if !ok {
+			t.Fatalf("expected a DeviceAttribute, got %T", object.Points[0])
+		}
+
+		attributes = append(attributes, attribute)
+	}
+
+	version, err := attributes[0].AsString()
+	if err != nil || version != "1.2.3" || attributes[0].Variation != 242 {
+		t.Fatalf("expected software version 1.2.3, got %q (%v)", version, err)
+	}
+
+	count, err := attributes[1].AsUint()
+	if err != nil || count != 300 || len(attributes[1].Value) != 2 {
+		t.Fatalf("expected 2-byte UINT 300, got %d (%v)", count, err)
+	}
+
+	signed, err := attributes[2].AsInt()
+	if err != nil || signed != -2 || len(attributes[2].Value) != 1 {
+		t.Fatalf("expected 1-byte INT -2, got %d (%v)", signed, err)
+	}
+
+	fraction, err := attributes[3].AsFloat()
+	if err != nil || fraction != 0.5 {
+		t.Fatalf("expected FLT 0.5, got %g (%v)", fraction, err)
+	}
+
+	if got, ok := attributes[4].GetValue().([]dnp3.AttributeVariation); !ok || !slices.Equal(got, list) {
+		t.Fatalf("expected %v, got %v", list, got)
+	}
+
+	_, err = attributes[0].AsUint()
+	if !errors.Is(err, dnp3.ErrAttributeType) {
+		t.Fatalf("expected ErrAttributeType, got %v", err)
+	}
+
+	if name := dnp3.AttributeName(252); name != "Device manufacturer's name" {
+		t.Fatalf("unexpected name for variation 252: %q", name)
+	}
+}
+
+func TestFileObjects(t *testing.T) {
+	t.Parallel()
+
+	created := dnp3.AbsoluteTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	want := []dnp3.Point{
+		&dnp3.FileCommand{Name: "events.log", Mode: dnp3.FileModeRead, MaxBlockSize: 1024, RequestID: 3},
+		&dnp3.FileCommandStatus{Handle: 7, Size: 2500, Status: dnp3.FileStatusNotFound, Text: "no such file"},
+		&dnp3.FileTransport{Handle: 7, Block: 2, Last: true, Data: []byte("tail")},
+		&dnp3.FileDescriptor{Name: "config.xml", Type: dnp3.FileTypeSimple, Size: 512, Created: created},
+	}
+
+	built := dnp3.ApplicationData{Objects: []dnp3.DataObject{
+		dnp3.NewSizedObject(70, 3, want[0]),
+		dnp3.NewSizedObject(70, 4, want[1]),
+		dnp3.NewSizedObject(70, 5, want[2]),
+		dnp3.NewSizedObject(70, 7, want[3]),
+	}}
+
+	encoded, err := built.SerializeTo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// g70v5, qualifier 0x5B, one 12-byte object: handle, block 2 with the
+	// last block bit, data.
+	transport := []byte{
+		0x46, 0x05, 0x5B, 0x01, 0x0C, 0x00,
+		0x07, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x80, 't', 'a', 'i', 'l',
+	}
+	if !bytes.Contains(encoded, transport) {
+		t.Fatalf("expected % X in % X", transport, encoded)
+	}
+
+	// An empty g70v3 is too short for a file command.
+	_, err = dnp3.NewApplicationDataFromBytes([]byte{0x46, 0x03, 0x5B, 0x01, 0x00, 0x00})
+	if err == nil {
+		t.Fatal("expected an empty file command to be rejected")
+	}
+
+	// A Read names the block it asks for with a g70v5 object.
+	for _, decode := range []func([]byte) (*dnp3.ApplicationData, error){
+		dnp3.NewApplicationDataFromBytes,
+		func(data []byte) (*dnp3.ApplicationData, error) {
+			req, err := dnp3.NewApplicationRequestFromBytes(append([]byte{0xC0, byte(dnp3.Read)}, data...))
+			if err != nil {
+				return nil, err
+			}
+
+			return &req.Data, nil
+		},
+	} {
+		data, err := decode(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, object := range data.Objects {
+			got, err := json.Marshal(object.Points[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected, err := json.Marshal(want[i])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(got, expected) {
+				t.Fatalf("object %d: expected %s, got %s", i, expected, got)
+			}
+		}
+	}
+}
+
+func TestOctetString(t *testing.T) {
+	t.Parallel()
+
+	var built dnp3.ApplicationData
+
+	for _, value := range []struct {
+		group uint8
+		text  string
+	}{{110, "fw 1.2.3"}, {113, "ok\r\n"}} {
+		variation := uint8(len(value.text))
+
+		points, err := dnp3.NewPoints(value.group, variation, dnp3.NoPrefix, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		point, ok := points[0].(*dnp3.PointBytes)
+		if !ok || point.ExpectedValueSize() != len(value.text) {
+			t.Fatalf("g%dv%d: expected a %d-byte PointBytes, got %T", value.group, variation, len(value.text), points[0])
+		}
+
+		err = point.SetString(value.text)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rangeField := dnp3.NewStartStopRangeField(0, 0)
+		built.Objects = append(built.Objects, dnp3.DataObject{
+			Header: dnp3.ObjectHeader{
+				Group: value.group, Variation: variation, RangeSpecCode: rangeField.Code(), RangeField: rangeField,
+			},
+			Points: points,
+		})
+	}
+
+	encoded, err := built.SerializeTo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte{0x6E, 0x08, 0x00, 0x00, 0x00, 'f', 'w', ' ', '1', '.', '2', '.', '3'}
+	if !bytes.HasPrefix(encoded, want) {
+		t.Fatalf("expected encoding to start with % X, got % X", want, encoded)
+	}
+
+	data, err := dnp3.NewApplicationDataFromBytes(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	point, ok := data.Objects[1].Points[0].(*dnp3.PointBytes)
+	if !ok || !point.IsOctetString() {
+		t.Fatalf("expected an octet string point, got %T", data.Objects[1].Points[0])
+	}
+
+	text, err := point.AsString()
+	if err != nil || text != "ok\r\n" {
+		t.Fatalf("expected %q, got %q (%v)", "ok\r\n", text, err)
+	}
+
+	if !strings.Contains(point.String(), `"ok\r\n"`) {
+		t.Fatalf("expected the text in String(), got %s", point)
+	}
+
+	encodedJSON, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(encodedJSON), `"text":"fw 1.2.3"`) {
+		t.Fatalf("expected the text in JSON, got %s", encodedJSON)
+	}
+
+	var decoded dnp3.ApplicationData
+
+	err = json.Unmarshal(encodedJSON, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reencoded, err := decoded.SerializeTo()
+	if err != nil || !bytes.Equal(reencoded, encoded) {
+		t.Fatalf("expected JSON to round trip to % X, got % X (%v)", encoded, reencoded, err)
+	}
+
+	counter, err := dnp3.NewPoints(20, 1, dnp3.NoPrefix, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counterPoint, ok := counter[0].(*dnp3.PointBytes)
+	if !ok {
+		t.Fatalf("expected a PointBytes counter, got %T", counter[0])
+	}
+
+	_, err = counterPoint.AsString()
+	if !errors.Is(err, dnp3.ErrNotOctetString) {
+		t.Fatalf("expected ErrNotOctetString, got %v", err)
+	}
+}
+
+func authObject(variation uint8, point dnp3.Point) dnp3.DataObject {
+	object := dnp3.NewSizedObject(120, variation, point)
+
+	// The aggressive mode request and key status request have a fixed size.
+	if variation == 3 || variation == 4 {
+		rangeField := dnp3.NewCountRangeField(1)
+		object.Header.PointPrefixCode = dnp3.NoPrefix
+		object.Header.RangeSpecCode = rangeField.Code()
+		object.Header.RangeField = rangeField
+	}
+
+	return object
+}
+
+func TestSecureAuthenticationObjects(t *testing.T) {
+	t.Parallel()
+
+	variations := []uint8{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	want := []dnp3.Point{
+		&dnp3.AuthChallenge{
+			Sequence: 9, User: 1, Algorithm: dnp3.MACSHA256Truncated16,
+			Reason: dnp3.ChallengeReasonCritical, Data: []byte{1, 2, 3, 4},
+		},
+		&dnp3.AuthReply{Sequence: 9, User: 1, MAC: bytes.Repeat([]byte{0xAB}, 16)},
+		&dnp3.AuthAggressiveRequest{Sequence: 10, User: 1},
+		&dnp3.AuthKeyStatusRequest{User: 1},
+		&dnp3.AuthKeyStatus{
+			KeySequence: 2, User: 1, KeyWrap: dnp3.KeyWrapAES128, Status: dnp3.KeyStatusNotInit,
+			Algorithm: dnp3.MACSHA256Truncated16, Challenge: []byte{5, 6, 7, 8}, MAC: []byte{},
+		},
+		&dnp3.AuthKeyChange{KeySequence: 2, User: 1, WrappedKeys: bytes.Repeat([]byte{0x11}, 48)},
+		&dnp3.AuthError{
+			Sequence: 9, User: 1, Association: 4, Code: dnp3.AuthErrorAuthenticationFailed,
+			Time: dnp3.AbsoluteTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)), Text: "bad MAC",
+		},
+		&dnp3.AuthUserCertificate{KeyChangeMethod: 67, CertificateType: 1, Certificate: []byte("cert")},
+		&dnp3.AuthMAC{MAC: bytes.Repeat([]byte{0xCD}, 16)},
+		&dnp3.AuthUserStatusChange{
+			KeyChangeMethod: 67, Operation: dnp3.UserStatusAdd, StatusSequence: 3, UserRole: 1,
+			UserRoleExpiry: 30, UserName: "operator", UserPublicKey: []byte{}, CertificationData: []byte{0xEE},
+		},
+		&dnp3.AuthUpdateKeyChangeRequest{KeyChangeMethod: 67, UserName: "operator", Challenge: []byte{1, 2, 3, 4}},
+		&dnp3.AuthUpdateKeyChangeReply{KeySequence: 4, User: 2, Challenge: []byte{5, 6, 7, 8}},
+		&dnp3.AuthUpdateKeyChange{KeySequence: 4, User: 2, EncryptedKey: bytes.Repeat([]byte{0x22}, 40)},
+		&dnp3.AuthUpdateKeySignature{Signature: bytes.Repeat([]byte{0x33}, 64)},
+		&dnp3.AuthUpdateKeyConfirmation{MAC: bytes.Repeat([]byte{0x44}, 16)},
+	}
+
+	var built dnp3.ApplicationData
+	for i, point := range want {
+		built.Objects = append(built.Objects, authObject(variations[i], point))
+	}
+
+	encoded, err := built.SerializeTo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// g120v4, qualifier 0x07, one user number.
+	keyStatusRequest := []byte{0x78, 0x04, 0x07, 0x01, 0x01, 0x00}
+	if !bytes.Contains(encoded, keyStatusRequest) {
+		t.Fatalf("expected % X in % X", keyStatusRequest, encoded)
+	}
+
+	// g120v12, qualifier 0x5B, one 12-byte object: key change sequence 4,
+	// user 2, then the 4 bytes of challenge data.
+	keyChangeReply := []byte{
+		0x78, 0x0C, 0x5B, 0x01, 0x0C, 0x00,
+		0x04, 0x00, 0x00, 0x00, 0x02, 0x00, 0x04, 0x00, 5, 6, 7, 8,
+	}
+	if !bytes.Contains(encoded, keyChangeReply) {
+		t.Fatalf("expected % X in % X", keyChangeReply, encoded)
+	}
+
+	// A challenge length past the end of the object is rejected.
+	truncated := slices.Clone(keyChangeReply)
+	truncated[12] = 0x09
+
+	_, err = dnp3.NewApplicationDataFromBytes(truncated)
+	if err == nil {
+		t.Fatal("expected a truncated update key change reply to be rejected")
+	}
+
+	// An empty g120v1 is too short for a challenge.
+	_, err = dnp3.NewApplicationDataFromBytes([]byte{0x78, 0x01, 0x5B, 0x01, 0x00, 0x00})
+	if err == nil {
+		t.Fatal("expected an empty challenge to be rejected")
+	}
+
+	data, err := dnp3.NewApplicationDataFromBytes(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encodedJSON, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded dnp3.ApplicationData
+
+	err = json.Unmarshal(encodedJSON, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, got := range []*dnp3.ApplicationData{data, &decoded} {
+		for i, object := range got.Objects {
+			gotJSON, err := json.Marshal(object.Points[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected, err := json.Marshal(want[i])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(gotJSON, expected) {
+				t.Fatalf("g120v%d: expected %s, got %s", variations[i], expected, gotJSON)
+			}
+		}
+	}
+
+	reencoded, err := decoded.SerializeTo()
+	if err != nil || !bytes.Equal(reencoded, encoded) {
+		t.Fatalf("expected JSON to round trip to % X, got % X (%v)", encoded, reencoded, err)
+	}
+}
+
+func TestAuthenticator(t *testing.T) {
+	t.Parallel()
+
+	updateKey := bytes.Repeat([]byte{0x42}, 16)
+	master := &dnp3.MasterAuthenticator{
+		User:      1,
+		UpdateKey: updateKey,
+		KeyWrap:   dnp3.KeyWrapAES128,
+		Rand:      bytes.NewReader(bytes.Repeat([]byte{0x01, 0x02, 0x03}, 100)),
+	}
+	outstation := &dnp3.OutstationAuthenticator{
+		User:      1,
+		UpdateKey: updateKey,
+		KeyWrap:   dnp3.KeyWrapAES128,
+		Algorithm: dnp3.MACSHA256Truncated16,
+		Rand:      bytes.NewReader(bytes.Repeat([]byte{0x0A, 0x0B}, 100)),
+	}
+
+	asdu := []byte{0xC1, byte(dnp3.DirOperate), 0x0C, 0x01, 0x17, 0x01, 0x00}
+
+	_, err := outstation.Challenge(asdu)
+	if !errors.Is(err, dnp3.ErrNoSessionKeys) {
+		t.Fatalf("expected ErrNoSessionKeys before a key change, got %v", err)
+	}
+
+	if master.KeyStatusRequest().User != 1 {
+		t.Fatal("expected a key status request for user 1")
+	}
+
+	status, err := outstation.KeyStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status.Status != dnp3.KeyStatusNotInit {
+		t.Fatalf("expected status %s, got %s", dnp3.KeyStatusNotInit, status.Status)
+	}
+
+	change, err := master.ChangeKeys(status)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, err = outstation.ChangeKeys(change)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = master.ConfirmKeys(status)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(master.Keys().Control, outstation.Keys().Control) ||
+		!bytes.Equal(master.Keys().Monitor, outstation.Keys().Monitor) {
+		t.Fatal("expected master and outstation to share the session keys")
+	}
+
+	challenge, err := outstation.Challenge(asdu)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply, err := master.Reply(challenge, asdu)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reply.MAC) != 16 {
+		t.Fatalf("expected a 16-byte MAC, got %d bytes", len(reply.MAC))
+	}
+
+	err = outstation.VerifyReply(reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A reply is accepted once, and only with the right MAC.
+	err = outstation.VerifyReply(reply)
+	if !errors.Is(err, dnp3.ErrAuthentication) {
+		t.Fatalf("expected a replayed reply to fail, got %v", err)
+	}
+
+	challenge, err = outstation.Challenge(asdu)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply, err = master.Reply(challenge, append(slices.Clone(asdu[:len(asdu)-1]), 0x01))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = outstation.VerifyReply(reply)
+	if !errors.Is(err, dnp3.ErrAuthentication) {
+		t.Fatalf("expected a reply for another request to fail, got %v", err)
+	}
+
+	// A key change wrapped with the wrong update key is refused.
+	status, err = outstation.KeyStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	intruder := &dnp3.MasterAuthenticator{User: 1, UpdateKey: bytes.Repeat([]byte{0x24}, 16), KeyWrap: dnp3.KeyWrapAES128}
+
+	change, err = intruder.ChangeKeys(status)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, err = outstation.ChangeKeys(change)
+	if !errors.Is(err, dnp3.ErrAuthentication) || status.Status != dnp3.KeyStatusAuthFail {
+		t.Fatalf("expected an AuthFail status, got %v (%v)", status, err)
+	}
+
+	if outstation.Keys() != nil {
+		t.Fatal("expected the session keys to be dropped")
+	}
+}
+
+func TestDataSets(t *testing.T) {
+	t.Parallel()
+
+	uuid := [16]byte{0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1A, 0x1B, 0x1C, 0x1D, 0x1E, 0x1F}
+	prototype := &dnp3.DataSetPrototype{ID: 0, UUID: uuid, Elements: []dnp3.DataSetElement{
+		{Code: dnp3.DataSetNAME, Type: dnp3.AttributeVSTR, MaxLength: 0, Ancillary: []byte("phase")},
+		{Code: dnp3.DataSetDAEL, Type: dnp3.AttributeFLT, MaxLength: 4, Ancillary: []byte("voltage")},
+		{Code: dnp3.DataSetDAEL, Type: dnp3.AttributeFLT, MaxLength: 4, Ancillary: []byte("current")},
+	}}
+	descriptor := &dnp3.DataSetDescriptor{ID: 5, Elements: []dnp3.DataSetElement{
+		{Code: dnp3.DataSetID, Type: dnp3.AttributeVSTR, MaxLength: 0, Ancillary: []byte("feeder")},
+		{Code: dnp3.DataSetDAEL, Type: dnp3.AttributeVSTR, MaxLength: 8, Ancillary: []byte("name")},
+		{Code: dnp3.DataSetPTYP, Type: 0, MaxLength: 0, Ancillary: uuid[:]},
+		{Code: dnp3.DataSetCTLS, Type: dnp3.AttributeUINT, MaxLength: 1, Ancillary: []byte("status")},
+	}}
+
+	voltage := binary.LittleEndian.AppendUint32(nil, math.Float32bits(120.5))
+	current := binary.LittleEndian.AppendUint32(nil, math.Float32bits(4.25))
+	values := [][]byte{[]byte("north"), voltage, current, {0x02}}
+
+	built := dnp3.ApplicationData{Objects: []dnp3.DataObject{
+		dnp3.NewSizedObject(85, 1, prototype),
+		dnp3.NewSizedObject(86, 1, descriptor),
+		dnp3.NewSizedObject(87, 1, &dnp3.DataSetValue{ID: 5, Values: values}),
+		dnp3.NewSizedObject(88, 1, &dnp3.DataSetEvent{
+			ID: 5, Time: dnp3.AbsoluteTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)), Values: values,
+		}),
+		dnp3.NewSizedObject(86, 3, &dnp3.DataSetPointIndexes{ID: 5, Points: []dnp3.DataSetPointIndex{
+			{Type: 30, Index: 7}, {Type: 40, Index: 300},
+		}}),
+	}}
+
+	encoded, err := built.SerializeTo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// g87v1, qualifier 0x5B, one 20-byte object: data set 5, then each value
+	// preceded by its length.
+	present := []byte{0x57, 0x01, 0x5B, 0x01, 0x14, 0x00, 0x05, 0x00, 0x05, 'n', 'o', 'r', 't', 'h', 0x04}
+	if !bytes.Contains(encoded, present) {
+		t.Fatalf("expected % X in % X", present, encoded)
+	}
+
+	// g86v3, qualifier 0x5B, one 9-byte object: data set 5, then each point
+	// type and index preceded by their length.
+	pointIndexes := []byte{0x56, 0x03, 0x5B, 0x01, 0x09, 0x00, 0x05, 0x00, 0x02, 0x1E, 0x07, 0x03, 0x28, 0x2C, 0x01}
+	if !bytes.Contains(encoded, pointIndexes) {
+		t.Fatalf("expected % X in % X", pointIndexes, encoded)
+	}
+
+	// An index keeps the width it was received with.
+	wideIndex := []byte{0x56, 0x03, 0x5B, 0x01, 0x06, 0x00, 0x05, 0x00, 0x03, 0x1E, 0x07, 0x00}
+
+	wideData, err := dnp3.NewApplicationDataFromBytes(wideIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reencodedWide, err := wideData.SerializeTo()
+	if err != nil || !bytes.Equal(reencodedWide, wideIndex) {
+		t.Fatalf("expected % X, got % X (%v)", wideIndex, reencodedWide, err)
+	}
+
+	data, err := dnp3.NewApplicationDataFromBytes(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The values can't be typed without their descriptor.
+	err = dnp3.NewDataSetRegistry().Resolve(&dnp3.ApplicationData{Objects: data.Objects[2:]})
+	if !errors.Is(err, dnp3.ErrUnknownDataSet) {
+		t.Fatalf("expected ErrUnknownDataSet, got %v", err)
+	}
+
+	err = dnp3.NewDataSetRegistry().Resolve(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []dnp3.DataSetField{
+		{Name: "name", Code: dnp3.DataSetDAEL, Type: dnp3.AttributeVSTR, Value: "north"},
+		{Name: "voltage", Code: dnp3.DataSetDAEL, Type: dnp3.AttributeFLT, Value: 120.5},
+		{Name: "current", Code: dnp3.DataSetDAEL, Type: dnp3.AttributeFLT, Value: 4.25},
+		{Name: "status", Code: dnp3.DataSetCTLS, Type: dnp3.AttributeUINT, Value: uint64(2)},
+	}
+
+	for _, point := range []dnp3.Point{data.Objects[2].Points[0], data.Objects[3].Points[0]} {
+		var resolved []dnp3.DataSetField
+
+		switch dataSet := point.(type) {
+		case *dnp3.DataSetValue:
+			resolved = dataSet.Resolved
+		case *dnp3.DataSetEvent:
+			resolved = dataSet.Resolved
+		default:
+			t.Fatalf("expected a data set value, got %T", point)
+		}
+
+		if fmt.Sprint(resolved) != fmt.Sprint(want) {
+			t.Fatalf("expected %v, got %v", want, resolved)
+		}
+	}
+
+	encodedJSON, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded dnp3.ApplicationData
+
+	err = json.Unmarshal(encodedJSON, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reencoded, err := decoded.SerializeTo()
+	if err != nil || !bytes.Equal(reencoded, encoded) {
+		t.Fatalf("expected JSON to round trip to % X, got % X (%v)", encoded, reencoded, err)
+	}
+}
+
+func TestAnalogOutputBlock(t *testing.T) {
+	t.Parallel()
+
+	object, err := dnp3.NewAnalogOutputObject(2, map[int]float64{300: -5, 4: 1200})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := object.SerializeTo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// g41v2, qualifier 0x28, two points: index, 16-bit setpoint, status.
+	want := []byte{0x29, 0x02, 0x28, 0x02, 0x00, 0x04, 0x00, 0xB0, 0x04, 0x00, 0x2C, 0x01, 0xFB, 0xFF, 0x00}
+	if !slices.Equal(encoded, want) {
+		t.Fatalf("expected % X, got % X", want, encoded)
+	}
+
+	decoded, err := dnp3.NewDataObjectFromBytes(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block, ok := decoded.Points[1].(*dnp3.AnalogOutputBlock)
+	if !ok {
+		t.Fatalf("expected an AnalogOutputBlock, got %T", decoded.Points[1])
+	}
+
+	if block.Value != -5 || block.NumericEncoding() != dnp3.NumericInt16 || block.Status != dnp3.CommandStatusSuccess {
+		t.Fatalf("unexpected analog output block %+v", block)
+	}
+
+	if !slices.Equal(decoded.Indexes(), []int{4, 300}) {
+		t.Fatalf("expected indexes [4 300], got %v", decoded.Indexes())
+	}
+
+	err = block.SetValue(40000)
+	if err == nil {
+		t.Fatal("expected 40000 not to fit a 16-bit setpoint")
+	}
+
+	block.Status = dnp3.CommandStatusOutOfRange
+
+	reencoded, err := decoded.SerializeTo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reencoded[len(reencoded)-1] != byte(dnp3.CommandStatusOutOfRange) {
+		t.Fatalf("expected the status octet last, got % X", reencoded)
+	}
+
+	// The reserved status bit decodes as received, but can't be sent.
+	reserved := slices.Clone(encoded)
+	reserved[len(reserved)-1] = 0x80
+
+	decoded, err = dnp3.NewDataObjectFromBytes(reserved)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block, ok = decoded.Points[1].(*dnp3.AnalogOutputBlock)
+	if !ok || block.Status != 0x80 {
+		t.Fatalf("expected status 128, got %+v", decoded.Points[1])
+	}
+
+	_, err = decoded.SerializeTo()
+	if err == nil {
+		t.Fatal("expected an error serializing status 128")
+	}
+
+	float, err := dnp3.NewAnalogOutputObject(3, map[int]float64{1: 2.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err = float.SerializeTo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want = []byte{0x29, 0x03, 0x17, 0x01, 0x01, 0x00, 0x00, 0x20, 0x40, 0x00}
+	if !slices.Equal(encoded, want) {
+		t.Fatalf("expected % X, got % X", want, encoded)
+	}
+}
+
+func TestRequestBuilder(t *testing.T) {
+	t.Parallel()
+
+	frame, err := dnp3.NewRequest(dnp3.Read).Sequence(3).Class(1, 2, 3).Range(30, 1, 0, 9).
+		Range(1, 2, 0, 300).Count(2, 0, 5).Frame(1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded := serializeFrame(t, frame)
+
+	decoded, err := dnp3.NewFrameFromBytes(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !decoded.DataLink.Control.Direction || !decoded.DataLink.Control.Primary ||
+		decoded.DataLink.Source != 1 || decoded.DataLink.Destination != 10 {
+		t.Fatalf("unexpected data link %+v", decoded.DataLink)
+	}
+
+	request, ok := decoded.Application.(*dnp3.ApplicationRequest)
+	if !ok {
+		t.Fatalf("expected a request, got %T", decoded.Application)
+	}
+
+	app, err := request.SerializeTo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte{
+		0xC3, 0x01,
+		0x3C, 0x02, 0x06, 0x3C, 0x03, 0x06, 0x3C, 0x04, 0x06,
+		0x1E, 0x01, 0x00, 0x00, 0x09,
+		0x01, 0x02, 0x01, 0x00, 0x00, 0x2C, 0x01,
+		0x02, 0x00, 0x07, 0x05,
+	}
+	if !bytes.Equal(app, want) {
+		t.Fatalf("expected % X, got % X", want, app)
+	}
+
+	analog, err := dnp3.NewAnalogOutputObject(1, map[int]float64{2: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operate, err := dnp3.NewRequest(dnp3.DirOperate).Object(*analog).Request()
+	if err != nil || len(operate.Data.Objects) != 1 || operate.FunctionCode != dnp3.DirOperate {
+		t.Fatalf("unexpected operate request %v (%v)", operate, err)
+	}
+
+	_, err = dnp3.NewRequest(dnp3.Read).Class(4).Range(30, 1, 9, 0).Frame(1, 10)
+	if err == nil || !strings.Contains(err.Error(), "class") || !strings.Contains(err.Error(), "range") {
+		t.Fatalf("expected class and range errors, got %v", err)
+	}
+}
+
+func TestResponseBuilder(t *testing.T) {
+	t.Parallel()
+
+	values := map[int]any{40: 40, 41: 41.0, 300: -300}
+	for index := range 10 {
+		values[index] = index
+	}
+
+	objects, err := dnp3.NewPointObjects(30, 1, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(objects) != 2 ||
+		objects[0].Header.RangeSpecCode != dnp3.StartStop1 || objects[0].Header.PointPrefixCode != dnp3.NoPrefix ||
+		objects[1].Header.RangeSpecCode != dnp3.Count2 || objects[1].Header.PointPrefixCode != dnp3.Index2Octet {
+		t.Fatalf("expected a start-stop and an index-prefixed object, got %v", objects)
+	}
+
+	if indexes := objects[1].Indexes(); !slices.Equal(indexes, []int{40, 41, 300}) {
+		t.Fatalf("expected indexes 40, 41, 300, got %v", indexes)
+	}
+
+	fragments, err := dnp3.NewResponse(dnp3.Response).Sequence(14).MaxFragmentSize(30).
+		IIN(dnp3.ApplicationInternalIndications{Restart: true}).Points(30, 1, values).Fragments()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 10 points of 5 bytes after a 5-byte header take three fragments of
+	// 26 bytes, and the prefixed object a fourth.
+	wantIndexes := [][]int{{0, 1, 2, 3}, {4, 5, 6, 7}, {8, 9}, {40, 41, 300}}
+	wantLast := []int64{3, 7, 9, -300}
+	if len(fragments) != len(wantIndexes) {
+		t.Fatalf("expected %d fragments, got %d", len(wantIndexes), len(fragments))
+	}
+
+	for i, fragment := range fragments {
+		want := dnp3.ApplicationControl{
+			First:    i == 0,
+			Final:    i == len(fragments)-1,
+			Confirm:  i != len(fragments)-1,
+			Sequence: uint8((14 + i) % 16), //nolint:gosec // G115 - test values
+		}
+		if fragment.Control != want {
+			t.Errorf("fragment %d: expected control %+v, got %+v", i, want, fragment.Control)
+		}
+
+		encoded, err := fragment.SerializeTo()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(encoded) > 30 {
+			t.Errorf("fragment %d is %d bytes", i, len(encoded))
+		}
+
+		decoded, err := dnp3.NewApplicationResponseFromBytes(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.InternalIndications.Restart || len(decoded.Data.Objects) != 1 {
+			t.Fatalf("fragment %d: unexpected %v", i, decoded)
+		}
+
+		object := decoded.Data.Objects[0]
+		if indexes := object.Indexes(); !slices.Equal(indexes, wantIndexes[i]) {
+			t.Errorf("fragment %d: expected indexes %v, got %v", i, wantIndexes[i], indexes)
+		}
+
+		last, ok := object.Points[len(object.Points)-1].(*dnp3.PointBytes)
+		if !ok {
+			t.Fatalf("expected *dnp3.PointBytes, got %T", object.Points[len(object.Points)-1])
+		}
+
+		value, err := last.AsInt64()
+		if err != nil || value != wantLast[i] {
+			t.Errorf("fragment %d: unexpected last value %d (%v)", i, value, err)
+		}
+	}
+
+	fragments, err = dnp3.NewResponse(dnp3.Response).Confirm().
+		Points(1, 1, map[int]any{0: true, 1: false, 9: true}).Fragments()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fragments) != 1 || !fragments[0].Control.Confirm || len(fragments[0].Data.Objects) != 2 ||
+		fragments[0].Data.Objects[1].Header.PointPrefixCode != dnp3.NoPrefix {
+		t.Fatalf("expected one confirmed fragment with two packed start-stop objects, got %v", fragments)
+	}
+
+	fragments, err = dnp3.NewResponse(dnp3.Response).Points(1, 2, map[int]any{3: true, 7: false}).Fragments()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := fragments[0].SerializeTo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := dnp3.NewApplicationResponseFromBytes(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if indexes := decoded.Data.Objects[0].Indexes(); !slices.Equal(indexes, []int{3, 7}) ||
+		decoded.Data.Objects[0].Points[0].GetValue() != true {
+		t.Fatalf("expected binary inputs 3 and 7 with index prefixes, got %v", decoded.Data.Objects)
+	}
+
+	_, err = dnp3.NewResponse(dnp3.UnsolicitedResponse).MaxFragmentSize(30).Points(30, 1, values).Fragments()
+	if err == nil {
+		t.Fatal("expected an error for an unsolicited response over one fragment")
+	}
+
+	_, err = dnp3.NewResponse(dnp3.Response).MaxFragmentSize(10).Points(30, 1, map[int]any{0: 1}).Fragments()
+	if err == nil {
+		t.Fatal("expected an error for a point larger than a fragment")
+	}
+}
+
+func violationRules(violations []dnp3.Violation) []dnp3.RuleID {
+	rules := make([]dnp3.RuleID, 0, len(violations))
+	for _, violation := range violations {
+		rules = append(rules, violation.Rule)
+	}
+
+	return rules
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range tests {
+		frame, err := dnp3.NewFrameFromBytes(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if violations := dnp3.Validate(frame); len(violations) > 0 {
+			t.Errorf("%s: unexpected violations %v", tc.name, violations)
+		}
+	}
+
+	outstationLink := dnp3.DataLink{Source: 10, Destination: 1}
+	outstationLink.Control.Primary = true
+	outstationLink.Control.FunctionCode = dnp3.UnconfirmedUserData
+
+	fragments, err := dnp3.NewResponse(dnp3.Response).Points(30, 1, map[int]any{1: 1, 3: 3}).Fragments()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Swap the indexes, which decoding accepts.
+	points := fragments[0].Data.Objects[0].Points
+	if points[0].SetIndex(3) != nil || points[1].SetIndex(1) != nil {
+		t.Fatal("can't set indexes")
+	}
+
+	frame := &dnp3.Frame{
+		DataLink:    outstationLink,
+		Transport:   dnp3.Transport{First: true, Final: true},
+		Application: fragments[0],
+	}
+
+	decoded, err := dnp3.NewFrameFromBytes(serializeFrame(t, frame))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type validateCase struct {
+		name  string
+		frame *dnp3.Frame
+		want  []dnp3.RuleID
+	}
+
+	cases := []validateCase{{"IndexOrder", decoded, []dnp3.RuleID{dnp3.RuleIndexOrder}}}
+
+	// A response sent as if by a master, with reserved bits set.
+	response := *fragments[0]
+	response.InternalIndications.Reserved2 = true
+	response.Data.Objects = slices.Clone(response.Data.Objects)
+	response.Data.Objects[0].Header.Reserved = true
+	reserved := *frame
+	reserved.DataLink.Control.Direction = true
+	reserved.Application = &response
+	cases = append(cases, validateCase{
+		"Reserved", &reserved, []dnp3.RuleID{dnp3.RuleFunctionDirection, dnp3.RuleIIN, dnp3.RuleReserved},
+	})
+
+	analog, err := dnp3.NewAnalogOutputObject(1, map[int]float64{0: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, request := range []struct {
+		name    string
+		builder *dnp3.RequestBuilder
+		want    []dnp3.RuleID
+	}{
+		{
+			"ReadValues", dnp3.NewRequest(dnp3.Read).Object(*analog),
+			[]dnp3.RuleID{dnp3.RuleObjectFunction, dnp3.RuleObjectValues},
+		},
+		{
+			"OperateClass", dnp3.NewRequest(dnp3.DirOperate).Class(1),
+			[]dnp3.RuleID{dnp3.RuleObjectFunction, dnp3.RuleQualifier},
+		},
+		{"ReadClass", dnp3.NewRequest(dnp3.Read).Class(0, 1).Range(30, 0, 0, 9), nil},
+	} {
+		frame, err := request.builder.Frame(1, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cases = append(cases, validateCase{request.name, frame, request.want})
+	}
+
+	// A command status with the reserved bit set, which only decoding lets
+	// through, so it is set once the frame is built.
+	blocked, err := dnp3.NewAnalogOutputObject(1, map[int]float64{0: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operate, err := dnp3.NewRequest(dnp3.DirOperate).Object(*blocked).Frame(1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block, ok := blocked.Points[0].(*dnp3.AnalogOutputBlock)
+	if !ok {
+		t.Fatalf("expected an AnalogOutputBlock, got %T", blocked.Points[0])
+	}
+
+	block.Status = 0x80
+	cases = append(cases, validateCase{"ReservedStatus", operate, []dnp3.RuleID{dnp3.RuleReserved}})
+
+	for _, tc := range cases {
+		violations := dnp3.Validate(tc.frame)
+		if rules := violationRules(violations); !slices.Equal(rules, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, violations)
+		}
+	}
+}
+
+func TestDecodeOptions(t *testing.T) {
+	t.Parallel()
+
+	fragments, err := dnp3.NewResponse(dnp3.Response).Points(30, 1, map[int]any{0: 1}).Fragments()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An unknown link function code, IIN 2.6, the reserved qualifier bit,
+	// and an unknown object.
+	response := fragments[0]
+	response.InternalIndications.Reserved1 = true
+	response.Data.Objects[0].Header.Reserved = true
+	response.Data.SetExtra([]byte{0xFE, 0x01, 0x00, 0x00, 0x00})
+
+	frame := &dnp3.Frame{Transport: dnp3.Transport{First: true, Final: true}, Application: response}
+	frame.DataLink.Control.Primary = true
+	frame.DataLink.Control.FunctionCode = dnp3.DataLinkPrimaryFunctionCode(0x7)
+	encoded := serializeFrame(t, frame)
+
+	// Corrupt the CRC of the first transport block too.
+	corrupted := slices.Clone(encoded)
+	corrupted[27]++
+
+	for _, tc := range []struct {
+		name   string
+		input  []byte
+		layers []string
+	}{
+		{"Anomalies", encoded, []string{"data link", "application", "application", "application"}},
+		{"BadCRC", corrupted, []string{"data link", "transport", "application", "application", "application"}},
+	} {
+		_, err = dnp3.NewFrameFromBytes(tc.input)
+		if err == nil {
+			t.Fatalf("%s: expected strict decoding to fail", tc.name)
+		}
+
+		lenient, err := dnp3.NewFrameFromBytesWithOptions(tc.input, dnp3.DecodeOptions{})
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+
+		layers := make([]string, 0, len(lenient.Warnings))
+		for _, warning := range lenient.Warnings {
+			layers = append(layers, warning.Layer)
+		}
+
+		if !slices.Equal(layers, tc.layers) {
+			t.Errorf("%s: expected warnings in %v, got %v", tc.name, tc.layers, lenient.Warnings)
+		}
+
+		decoded, ok := lenient.Application.(*dnp3.ApplicationResponse)
+		if !ok {
+			t.Fatalf("%s: expected a response, got %T", tc.name, lenient.Application)
+		}
+
+		if !decoded.InternalIndications.Reserved1 || len(decoded.Data.Objects) != 1 ||
+			!decoded.Data.Objects[0].Header.Reserved || !bytes.Equal(decoded.Data.GetExtra(), response.Data.GetExtra()) {
+			t.Errorf("%s: anomalies not kept: %v", tc.name, decoded)
+		}
+
+		if reencoded := serializeFrame(t, lenient); !bytes.Equal(reencoded, encoded) {
+			t.Errorf("%s: expected % X, got % X", tc.name, encoded, reencoded)
+		}
+	}
+
+	// A fragment too short for a response is kept raw.
+	short := &dnp3.Frame{Transport: dnp3.Transport{First: true, Final: true}, Segment: []byte{0xC0, 0x81}}
+	short.DataLink.Control.Primary = true
+	short.DataLink.Control.FunctionCode = dnp3.UnconfirmedUserData
+
+	lenient, err := dnp3.NewFrameFromBytesWithOptions(serializeFrame(t, short), dnp3.DecodeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if lenient.Application != nil || !bytes.Equal(lenient.Segment, short.Segment) || len(lenient.Warnings) != 1 {
+		t.Fatalf("expected the raw fragment and one warning, got %v", lenient)
+	}
+}
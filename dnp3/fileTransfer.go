@@ -0,0 +1,615 @@
+package dnp3
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PointDataTypeFile identifies a file transfer (Group 70) object.
+const PointDataTypeFile PointDataType = "file"
+
+// FileStatus is the status code an outstation returns for a file operation
+// (Group 70 Var 4 and Var 6).
+//
+//go:generate stringer -type=FileStatus -trimprefix=FileStatus
+type FileStatus uint8
+
+const (
+	FileStatusSuccess FileStatus = iota
+	FileStatusPermissionDenied
+	FileStatusInvalidMode
+	FileStatusNotFound
+	FileStatusLocked
+	FileStatusTooManyOpen
+	FileStatusInvalidHandle
+	FileStatusWriteBlockSize
+	FileStatusCommLost
+	FileStatusCannotAbort
+	FileStatusNotOpened     FileStatus = 16
+	FileStatusHandleExpired FileStatus = 17
+	FileStatusBufferOverrun FileStatus = 18
+	FileStatusFatal         FileStatus = 19
+	FileStatusBlockSequence FileStatus = 20
+	FileStatusUndefined     FileStatus = 255
+)
+
+// FileMode is the operational mode of a file command (Group 70 Var 3).
+//
+//go:generate stringer -type=FileMode -trimprefix=FileMode
+type FileMode uint16
+
+const (
+	FileModeNull FileMode = iota // used by DeleteFile and GetFileInformation
+	FileModeRead
+	FileModeWrite
+	FileModeAppend
+)
+
+// FileType is the type of a file descriptor (Group 70 Var 7).
+//
+//go:generate stringer -type=FileType -trimprefix=FileType
+type FileType uint16
+
+const (
+	FileTypeDirectory FileType = iota
+	FileTypeSimple
+)
+
+// fileLastBlock is the bit of a block number that marks the last block.
+const fileLastBlock = 0x80000000
+
+// fileObject is the base of the Group 70 objects.
+type fileObject struct {
+	sizedObject
+}
+
+func (f *fileObject) DataType() PointDataType { return PointDataTypeFile }
+
+// FileAuthentication (Group 70 Var 2) asks the outstation for an
+// authentication key with AuthenticateFile; the response carries the key.
+type FileAuthentication struct {
+	fileObject
+
+	UserName          string `json:"user_name"`
+	Password          string `json:"password"`
+	AuthenticationKey uint32 `json:"authentication_key"`
+}
+
+func (p *FileAuthentication) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 12 {
+		return fmt.Errorf("file authentication requires at least 12 bytes, got %d", len(body))
+	}
+
+	p.UserName, err = fileString(body, body[0:4])
+	if err != nil {
+		return fmt.Errorf("user name: %w", err)
+	}
+
+	p.Password, err = fileString(body, body[4:8])
+	if err != nil {
+		return fmt.Errorf("password: %w", err)
+	}
+
+	p.AuthenticationKey = binary.LittleEndian.Uint32(body[8:12])
+
+	return nil
+}
+
+func (p *FileAuthentication) SerializeTo() ([]byte, error) {
+	body := appendFileString(nil, 12, p.UserName)
+	body = appendFileString(body, 12+len(p.UserName), p.Password)
+	body = binary.LittleEndian.AppendUint32(body, p.AuthenticationKey)
+	body = append(body, p.UserName...)
+	body = append(body, p.Password...)
+
+	return p.prefixed(body)
+}
+
+func (p *FileAuthentication) String() string {
+	return strings.Join([]string{
+		"User Name: " + p.UserName,
+		"Password : " + strings.Repeat("*", len(p.Password)),
+		fmt.Sprintf("Auth Key : 0x%08X", p.AuthenticationKey),
+	}, "\n")
+}
+
+func (p *FileAuthentication) GetValue() any { return *p }
+
+func (p *FileAuthentication) SetValue(value any) error {
+	val, ok := value.(FileAuthentication)
+	if !ok {
+		return fmt.Errorf("file authentication value must be a FileAuthentication, got %T", value)
+	}
+
+	val.fileObject = p.fileObject
+	*p = val
+
+	return nil
+}
+
+// FileCommand (Group 70 Var 3) names the file of an OpenFile or DeleteFile
+// request.
+type FileCommand struct {
+	fileObject
+
+	Name              string       `json:"name"`
+	Created           AbsoluteTime `json:"created"`
+	Permissions       uint16       `json:"permissions"`
+	AuthenticationKey uint32       `json:"authentication_key"`
+	Size              uint32       `json:"size"`
+	Mode              FileMode     `json:"mode"`
+	MaxBlockSize      uint16       `json:"max_block_size"`
+	RequestID         uint16       `json:"request_id"`
+}
+
+func (p *FileCommand) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 26 {
+		return fmt.Errorf("file command requires at least 26 bytes, got %d", len(body))
+	}
+
+	p.Name, err = fileString(body, body[0:4])
+	if err != nil {
+		return fmt.Errorf("file name: %w", err)
+	}
+
+	p.Created, err = fileTime(body[4:10])
+	if err != nil {
+		return fmt.Errorf("time of creation: %w", err)
+	}
+
+	p.Permissions = binary.LittleEndian.Uint16(body[10:12])
+	p.AuthenticationKey = binary.LittleEndian.Uint32(body[12:16])
+	p.Size = binary.LittleEndian.Uint32(body[16:20])
+	p.Mode = FileMode(binary.LittleEndian.Uint16(body[20:22]))
+	p.MaxBlockSize = binary.LittleEndian.Uint16(body[22:24])
+	p.RequestID = binary.LittleEndian.Uint16(body[24:26])
+
+	return nil
+}
+
+func (p *FileCommand) SerializeTo() ([]byte, error) {
+	created, err := fileTimeBytes(p.Created)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode time of creation: %w", err)
+	}
+
+	body := appendFileString(nil, 26, p.Name)
+	body = append(body, created...)
+	body = binary.LittleEndian.AppendUint16(body, p.Permissions)
+	body = binary.LittleEndian.AppendUint32(body, p.AuthenticationKey)
+	body = binary.LittleEndian.AppendUint32(body, p.Size)
+	body = binary.LittleEndian.AppendUint16(body, uint16(p.Mode))
+	body = binary.LittleEndian.AppendUint16(body, p.MaxBlockSize)
+	body = binary.LittleEndian.AppendUint16(body, p.RequestID)
+	body = append(body, p.Name...)
+
+	return p.prefixed(body)
+}
+
+func (p *FileCommand) String() string {
+	return strings.Join([]string{
+		"Name       : " + p.Name,
+		"Created    : " + p.Created.String(),
+		fmt.Sprintf("Permissions: %#o", p.Permissions),
+		fmt.Sprintf("Auth Key   : 0x%08X", p.AuthenticationKey),
+		fmt.Sprintf("Size       : %d", p.Size),
+		fmt.Sprintf("Mode       : (%d) %s", p.Mode, p.Mode),
+		fmt.Sprintf("Block Size : %d", p.MaxBlockSize),
+		fmt.Sprintf("Request ID : %d", p.RequestID),
+	}, "\n")
+}
+
+func (p *FileCommand) GetValue() any { return *p }
+
+func (p *FileCommand) SetValue(value any) error {
+	val, ok := value.(FileCommand)
+	if !ok {
+		return fmt.Errorf("file command value must be a FileCommand, got %T", value)
+	}
+
+	val.fileObject = p.fileObject
+	*p = val
+
+	return nil
+}
+
+// FileCommandStatus (Group 70 Var 4) answers OpenFile, CloseFile, DeleteFile
+// and AbortFile, and names the file handle of a CloseFile or AbortFile
+// request.
+type FileCommandStatus struct {
+	fileObject
+
+	Handle       uint32     `json:"handle"`
+	Size         uint32     `json:"size"`
+	MaxBlockSize uint16     `json:"max_block_size"`
+	RequestID    uint16     `json:"request_id"`
+	Status       FileStatus `json:"status"`
+	// Text is optional vendor-specific detail about the status.
+	Text string `json:"text"`
+}
+
+func (p *FileCommandStatus) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 13 {
+		return fmt.Errorf("file command status requires at least 13 bytes, got %d", len(body))
+	}
+
+	p.Handle = binary.LittleEndian.Uint32(body[0:4])
+	p.Size = binary.LittleEndian.Uint32(body[4:8])
+	p.MaxBlockSize = binary.LittleEndian.Uint16(body[8:10])
+	p.RequestID = binary.LittleEndian.Uint16(body[10:12])
+	p.Status = FileStatus(body[12])
+	p.Text = string(body[13:])
+
+	return nil
+}
+
+func (p *FileCommandStatus) SerializeTo() ([]byte, error) {
+	body := binary.LittleEndian.AppendUint32(nil, p.Handle)
+	body = binary.LittleEndian.AppendUint32(body, p.Size)
+	body = binary.LittleEndian.AppendUint16(body, p.MaxBlockSize)
+	body = binary.LittleEndian.AppendUint16(body, p.RequestID)
+	body = append(body, byte(p.Status))
+	body = append(body, p.Text...)
+
+	return p.prefixed(body)
+}
+
+func (p *FileCommandStatus) String() string {
+	parts := []string{
+		fmt.Sprintf("Handle    : 0x%08X", p.Handle),
+		fmt.Sprintf("Size      : %d", p.Size),
+		fmt.Sprintf("Block Size: %d", p.MaxBlockSize),
+		fmt.Sprintf("Request ID: %d", p.RequestID),
+		fmt.Sprintf("Status    : (%d) %s", p.Status, p.Status),
+	}
+
+	if p.Text != "" {
+		parts = append(parts, "Text      : "+p.Text)
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+func (p *FileCommandStatus) GetValue() any { return *p }
+
+func (p *FileCommandStatus) SetValue(value any) error {
+	val, ok := value.(FileCommandStatus)
+	if !ok {
+		return fmt.Errorf("file command status value must be a FileCommandStatus, got %T", value)
+	}
+
+	val.fileObject = p.fileObject
+	*p = val
+
+	return nil
+}
+
+// FileTransport (Group 70 Var 5) carries one block of file data. Block
+// numbers start at 0, and Last marks the final block of the file. A Read
+// request asks for a block with an empty Data.
+type FileTransport struct {
+	fileObject
+
+	Handle uint32 `json:"handle"`
+	Block  uint32 `json:"block"`
+	Last   bool   `json:"last"`
+	Data   []byte `json:"data"`
+}
+
+func (p *FileTransport) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 8 {
+		return fmt.Errorf("file transport requires at least 8 bytes, got %d", len(body))
+	}
+
+	p.Handle = binary.LittleEndian.Uint32(body[0:4])
+	p.Block, p.Last = splitBlock(binary.LittleEndian.Uint32(body[4:8]))
+	p.Data = slices.Clone(body[8:])
+
+	return nil
+}
+
+func (p *FileTransport) SerializeTo() ([]byte, error) {
+	body := binary.LittleEndian.AppendUint32(nil, p.Handle)
+	body = binary.LittleEndian.AppendUint32(body, joinBlock(p.Block, p.Last))
+	body = append(body, p.Data...)
+
+	return p.prefixed(body)
+}
+
+func (p *FileTransport) String() string {
+	return strings.Join([]string{
+		fmt.Sprintf("Handle: 0x%08X", p.Handle),
+		fmt.Sprintf("Block : %d", p.Block),
+		fmt.Sprintf("Last  : %t", p.Last),
+		fmt.Sprintf("Data  : %d bytes", len(p.Data)),
+	}, "\n")
+}
+
+// GetValue returns the block's data.
+func (p *FileTransport) GetValue() any { return slices.Clone(p.Data) }
+
+// SetValue sets the block's data from a []byte.
+func (p *FileTransport) SetValue(value any) error {
+	val, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("file transport value must be []byte, got %T", value)
+	}
+
+	p.Data = slices.Clone(val)
+
+	return nil
+}
+
+// FileTransportStatus (Group 70 Var 6) reports why a block couldn't be read
+// or written.
+type FileTransportStatus struct {
+	fileObject
+
+	Handle uint32     `json:"handle"`
+	Block  uint32     `json:"block"`
+	Last   bool       `json:"last"`
+	Status FileStatus `json:"status"`
+	// Text is optional vendor-specific detail about the status.
+	Text string `json:"text"`
+}
+
+func (p *FileTransportStatus) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 9 {
+		return fmt.Errorf("file transport status requires at least 9 bytes, got %d", len(body))
+	}
+
+	p.Handle = binary.LittleEndian.Uint32(body[0:4])
+	p.Block, p.Last = splitBlock(binary.LittleEndian.Uint32(body[4:8]))
+	p.Status = FileStatus(body[8])
+	p.Text = string(body[9:])
+
+	return nil
+}
+
+func (p *FileTransportStatus) SerializeTo() ([]byte, error) {
+	body := binary.LittleEndian.AppendUint32(nil, p.Handle)
+	body = binary.LittleEndian.AppendUint32(body, joinBlock(p.Block, p.Last))
+	body = append(body, byte(p.Status))
+	body = append(body, p.Text...)
+
+	return p.prefixed(body)
+}
+
+func (p *FileTransportStatus) String() string {
+	parts := []string{
+		fmt.Sprintf("Handle: 0x%08X", p.Handle),
+		fmt.Sprintf("Block : %d", p.Block),
+		fmt.Sprintf("Last  : %t", p.Last),
+		fmt.Sprintf("Status: (%d) %s", p.Status, p.Status),
+	}
+
+	if p.Text != "" {
+		parts = append(parts, "Text  : "+p.Text)
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+func (p *FileTransportStatus) GetValue() any { return *p }
+
+func (p *FileTransportStatus) SetValue(value any) error {
+	val, ok := value.(FileTransportStatus)
+	if !ok {
+		return fmt.Errorf("file transport status value must be a FileTransportStatus, got %T", value)
+	}
+
+	val.fileObject = p.fileObject
+	*p = val
+
+	return nil
+}
+
+// FileDescriptor (Group 70 Var 7) describes a file, in answer to
+// GetFileInformation or as an entry of a directory read.
+type FileDescriptor struct {
+	fileObject
+
+	Name        string       `json:"name"`
+	Type        FileType     `json:"type"`
+	Size        uint32       `json:"size"`
+	Created     AbsoluteTime `json:"created"`
+	Permissions uint16       `json:"permissions"`
+	RequestID   uint16       `json:"request_id"`
+}
+
+func (p *FileDescriptor) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 20 {
+		return fmt.Errorf("file descriptor requires at least 20 bytes, got %d", len(body))
+	}
+
+	p.Name, err = fileString(body, body[0:4])
+	if err != nil {
+		return fmt.Errorf("file name: %w", err)
+	}
+
+	p.Type = FileType(binary.LittleEndian.Uint16(body[4:6]))
+	p.Size = binary.LittleEndian.Uint32(body[6:10])
+
+	p.Created, err = fileTime(body[10:16])
+	if err != nil {
+		return fmt.Errorf("time of creation: %w", err)
+	}
+
+	p.Permissions = binary.LittleEndian.Uint16(body[16:18])
+	p.RequestID = binary.LittleEndian.Uint16(body[18:20])
+
+	return nil
+}
+
+func (p *FileDescriptor) SerializeTo() ([]byte, error) {
+	created, err := fileTimeBytes(p.Created)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode time of creation: %w", err)
+	}
+
+	body := appendFileString(nil, 20, p.Name)
+	body = binary.LittleEndian.AppendUint16(body, uint16(p.Type))
+	body = binary.LittleEndian.AppendUint32(body, p.Size)
+	body = append(body, created...)
+	body = binary.LittleEndian.AppendUint16(body, p.Permissions)
+	body = binary.LittleEndian.AppendUint16(body, p.RequestID)
+	body = append(body, p.Name...)
+
+	return p.prefixed(body)
+}
+
+func (p *FileDescriptor) String() string {
+	return strings.Join([]string{
+		"Name       : " + p.Name,
+		fmt.Sprintf("Type       : (%d) %s", p.Type, p.Type),
+		fmt.Sprintf("Size       : %d", p.Size),
+		"Created    : " + p.Created.String(),
+		fmt.Sprintf("Permissions: %#o", p.Permissions),
+		fmt.Sprintf("Request ID : %d", p.RequestID),
+	}, "\n")
+}
+
+func (p *FileDescriptor) GetValue() any { return *p }
+
+func (p *FileDescriptor) SetValue(value any) error {
+	val, ok := value.(FileDescriptor)
+	if !ok {
+		return fmt.Errorf("file descriptor value must be a FileDescriptor, got %T", value)
+	}
+
+	val.fileObject = p.fileObject
+	*p = val
+
+	return nil
+}
+
+// FileSpecification (Group 70 Var 8) is a file specification string, such as
+// a file name with wildcards.
+type FileSpecification struct {
+	fileObject
+
+	Specification string `json:"specification"`
+}
+
+func (p *FileSpecification) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	p.Specification = string(body)
+
+	return nil
+}
+
+func (p *FileSpecification) SerializeTo() ([]byte, error) {
+	return p.prefixed([]byte(p.Specification))
+}
+
+func (p *FileSpecification) String() string {
+	return "Specification: " + p.Specification
+}
+
+// GetValue returns the specification string.
+func (p *FileSpecification) GetValue() any { return p.Specification }
+
+// SetValue sets the specification from a string.
+func (p *FileSpecification) SetValue(value any) error {
+	val, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("file specification value must be a string, got %T", value)
+	}
+
+	p.Specification = val
+
+	return nil
+}
+
+// fileString returns the string located by a 2-byte offset and 2-byte size
+// pair, with the offset counted from the start of the object.
+func fileString(body, location []byte) (string, error) {
+	offset := int(binary.LittleEndian.Uint16(location[0:2]))
+	size := int(binary.LittleEndian.Uint16(location[2:4]))
+
+	if size == 0 {
+		return "", nil
+	}
+
+	if offset+size > len(body) {
+		return "", fmt.Errorf("%d bytes at offset %d overrun the %d-byte object", size, offset, len(body))
+	}
+
+	return string(body[offset : offset+size]), nil
+}
+
+// appendFileString appends the offset and size of a string stored at offset.
+func appendFileString(body []byte, offset int, value string) []byte {
+	//nolint:gosec // G115 - objects are bounded by their size prefix
+	body = binary.LittleEndian.AppendUint16(body, uint16(offset))
+
+	//nolint:gosec // G115 - objects are bounded by their size prefix
+	return binary.LittleEndian.AppendUint16(body, uint16(len(value)))
+}
+
+// fileTime decodes a time of creation, leaving it zero when the outstation
+// doesn't keep one (0 milliseconds).
+func fileTime(data []byte) (AbsoluteTime, error) {
+	if slices.Equal(data, make([]byte, len(data))) {
+		return AbsoluteTime{}, nil
+	}
+
+	return BytesToDNP3TimeAbsolute(data)
+}
+
+// fileTimeBytes encodes a time of creation, a zero time as 0 milliseconds.
+func fileTimeBytes(value AbsoluteTime) ([]byte, error) {
+	if value.Time().IsZero() {
+		return make([]byte, 6), nil
+	}
+
+	return TimeAbsoluteToBytes(value)
+}
+
+func splitBlock(block uint32) (uint32, bool) {
+	return block &^ fileLastBlock, block&fileLastBlock != 0
+}
+
+func joinBlock(block uint32, last bool) uint32 {
+	if last {
+		block |= fileLastBlock
+	}
+
+	return block
+}
@@ -0,0 +1,159 @@
+package dnp3
+
+import (
+	"bytes"
+	"io"
+	"slices"
+)
+
+// FramerStats counts the frames a Framer has produced and the input it had to
+// throw away to find them.
+type FramerStats struct {
+	// Frames is the number of frames yielded.
+	Frames int
+	// DiscardedBytes is the number of input bytes skipped while hunting for
+	// the next start of frame.
+	DiscardedBytes int
+	// CRCErrors is the number of candidate frames whose header or data
+	// block CRCs didn't match.
+	CRCErrors int
+	// InvalidFrames is the number of frames with valid CRCs that still
+	// failed to decode. Their bytes are counted in DiscardedBytes.
+	InvalidFrames int
+}
+
+// Framer extracts frames from a byte stream that may contain noise, such as a
+// serial line or a replayed capture. Unlike ParseFrames, it never gives up on
+// bad input: bytes that can't start a valid frame are discarded until the
+// next 0x05 0x64 start sequence, and every discard is counted in the Stats.
+//
+// Bytes are either pushed in with Push, or read from an io.Reader by Next.
+type Framer struct {
+	reader  io.Reader
+	pending []byte
+	frames  []*Frame
+	stats   FramerStats
+}
+
+// NewFramer returns a Framer that reads from r in Next. r may be nil if the
+// stream is fed with Push instead.
+func NewFramer(r io.Reader) *Framer {
+	return &Framer{reader: r}
+}
+
+// Stats returns the counters accumulated so far.
+func (f *Framer) Stats() FramerStats {
+	return f.stats
+}
+
+// Buffered returns the number of bytes held back as the start of an
+// incomplete frame.
+func (f *Framer) Buffered() int {
+	return len(f.pending)
+}
+
+// Push adds data to the stream and returns the frames it completes, in order.
+func (f *Framer) Push(data []byte) []*Frame {
+	f.pending = append(f.pending, data...)
+	f.extract()
+
+	frames := f.frames
+	f.frames = nil
+
+	return frames
+}
+
+// Next returns the next frame read from the underlying io.Reader, reading as
+// much as it needs. Once reading fails, the frames already completed are
+// still returned, then the read error (io.EOF at the end of the stream).
+func (f *Framer) Next() (*Frame, error) {
+	buf := make([]byte, 4096)
+
+	for len(f.frames) == 0 {
+		n, err := f.reader.Read(buf)
+
+		f.pending = append(f.pending, buf[:n]...)
+		f.extract()
+
+		if len(f.frames) == 0 && err != nil {
+			return nil, err //nolint:wrapcheck // io.EOF must reach the caller as is
+		}
+	}
+
+	frame := f.frames[0]
+	f.frames = f.frames[1:]
+
+	return frame, nil
+}
+
+// extract moves every complete frame at the front of the pending bytes to
+// f.frames, discarding whatever can't be the start of a valid frame.
+func (f *Framer) extract() {
+	start := []byte{0x05, 0x64}
+
+	for {
+		sync := bytes.Index(f.pending, start)
+		if sync < 0 {
+			// Keep a trailing 0x05 in case the 0x64 is still to come.
+			keep := 0
+			if len(f.pending) > 0 && f.pending[len(f.pending)-1] == start[0] {
+				keep = 1
+			}
+
+			f.discard(len(f.pending) - keep)
+
+			return
+		}
+
+		f.discard(sync)
+
+		if len(f.pending) < 10 {
+			return
+		}
+
+		header := f.pending[:10]
+		if !slices.Equal(CalculateDNP3CRC(header[:8]), header[8:]) {
+			f.stats.CRCErrors++
+			f.discard(1)
+
+			continue
+		}
+
+		total := frameWireSize(header[2])
+		if total == 0 {
+			f.discard(1)
+
+			continue
+		}
+
+		if len(f.pending) < total {
+			return
+		}
+
+		_, _, err := RemoveDNP3CRCs(f.pending[10:total])
+		if err != nil {
+			f.stats.CRCErrors++
+			f.discard(1)
+
+			continue
+		}
+
+		frame, err := NewFrameFromBytes(f.pending[:total])
+		if err != nil {
+			f.stats.InvalidFrames++
+			f.discard(total)
+
+			continue
+		}
+
+		f.frames = append(f.frames, frame)
+		f.stats.Frames++
+		f.pending = f.pending[total:]
+	}
+}
+
+// discard drops the first n pending bytes.
+func (f *Framer) discard(n int) {
+	f.stats.DiscardedBytes += n
+	f.pending = f.pending[n:]
+}
@@ -0,0 +1,365 @@
+package dnp3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+)
+
+// DefaultLinkTimeout is used when LinkConfig.Timeout is zero.
+const DefaultLinkTimeout = time.Second
+
+// Sentinel errors returned by the LinkLayer primary station services.
+var (
+	ErrLinkTimeout = errors.New("no data link response from the secondary station")
+	ErrLinkNack    = errors.New("data link request rejected by the secondary station")
+	ErrLinkReply   = errors.New("unexpected data link response")
+)
+
+// LinkConfig holds the addresses and retry policy of a LinkLayer.
+type LinkConfig struct {
+	// LocalAddress is the source of every frame sent. Frames addressed to
+	// anything else except the broadcast addresses are ignored by Receive.
+	LocalAddress uint16
+	// RemoteAddress is the destination of every frame sent. Only responses
+	// from it complete a primary station transaction.
+	RemoteAddress uint16
+	// Master sets the DIR bit of every frame sent.
+	Master bool
+	// Confirmed sends user data as ConfirmedUserData, resetting the link
+	// first when needed and waiting for an Ack. Otherwise user data is sent
+	// as UnconfirmedUserData and Send returns once it is written.
+	Confirmed bool
+	// Retries is the number of times a confirmed frame is retransmitted when
+	// no response arrives in time.
+	Retries int
+	// Timeout bounds the wait for each response. Zero uses
+	// DefaultLinkTimeout.
+	Timeout time.Duration
+}
+
+// LinkLayer implements the data link services of a station: the primary
+// station sends frames, tracking the frame count bit (FCB) and retransmitting
+// confirmed frames that are not acknowledged, and the secondary station
+// answers link requests from the remote station and discards retransmitted
+// user data it has already delivered.
+//
+// Every frame read from the connection must be passed to Receive, which also
+// completes the pending Send, ResetLink, TestLink or RequestLinkStatus.
+type LinkLayer struct {
+	config LinkConfig
+
+	writeMu sync.Mutex
+	writer  io.Writer
+
+	// sendMu serializes the primary station transactions.
+	sendMu  sync.Mutex
+	replies chan DataLinkSecondaryFunctionCode
+
+	mu sync.Mutex // guards the fields below
+	// Primary station state.
+	linkReset bool
+	fcb       bool
+	// Secondary station state.
+	remoteReset bool
+	expectedFCB bool
+}
+
+// NewLinkLayer returns a LinkLayer that writes frames to w. The link starts
+// out not reset in both directions.
+func NewLinkLayer(w io.Writer, config LinkConfig) *LinkLayer {
+	return &LinkLayer{
+		config:  config,
+		writer:  w,
+		replies: make(chan DataLinkSecondaryFunctionCode, 1),
+	}
+}
+
+// Send sends frame to the remote station, filling in its data link header.
+// With LinkConfig.Confirmed the link is reset if needed, and the frame is
+// retransmitted with the same FCB until it is acknowledged or the retries run
+// out; a Nack resets the link and sends the frame once more.
+func (l *LinkLayer) Send(ctx context.Context, frame *Frame) error {
+	if !l.config.Confirmed {
+		l.header(frame, UnconfirmedUserData, false, false)
+
+		return l.write(frame)
+	}
+
+	l.sendMu.Lock()
+	defer l.sendMu.Unlock()
+
+	err := l.sendConfirmed(ctx, frame)
+	if errors.Is(err, ErrLinkNack) {
+		err = l.sendConfirmed(ctx, frame)
+	}
+
+	return err
+}
+
+// sendConfirmed sends frame as ConfirmedUserData once, resetting the link
+// first if needed. The caller holds l.sendMu.
+func (l *LinkLayer) sendConfirmed(ctx context.Context, frame *Frame) error {
+	l.mu.Lock()
+	linkReset := l.linkReset
+	l.mu.Unlock()
+
+	if !linkReset {
+		err := l.resetLink(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return l.counted(ctx, frame, ConfirmedUserData)
+}
+
+// ResetLink resets the link: the secondary station then expects the FCB to be
+// set on the next confirmed frame.
+func (l *LinkLayer) ResetLink(ctx context.Context) error {
+	l.sendMu.Lock()
+	defer l.sendMu.Unlock()
+
+	return l.resetLink(ctx)
+}
+
+func (l *LinkLayer) resetLink(ctx context.Context) error {
+	frame := NewFrame()
+	l.header(frame, ResetLinkStates, false, false)
+
+	err := l.transact(ctx, frame, Ack)
+	if err != nil {
+		return fmt.Errorf("resetting link: %w", err)
+	}
+
+	l.mu.Lock()
+	l.linkReset = true
+	l.fcb = true
+	l.mu.Unlock()
+
+	return nil
+}
+
+// TestLink sends TestLinkStates, resetting the link first if needed.
+func (l *LinkLayer) TestLink(ctx context.Context) error {
+	l.sendMu.Lock()
+	defer l.sendMu.Unlock()
+
+	l.mu.Lock()
+	linkReset := l.linkReset
+	l.mu.Unlock()
+
+	if !linkReset {
+		return l.resetLink(ctx)
+	}
+
+	return l.counted(ctx, NewFrame(), TestLinkStates)
+}
+
+// counted sends a frame that carries a valid FCB and toggles the FCB once it
+// is acknowledged. A failure leaves the link to be reset before the next
+// confirmed frame. The caller holds l.sendMu.
+func (l *LinkLayer) counted(ctx context.Context, frame *Frame, code DataLinkPrimaryFunctionCode) error {
+	l.mu.Lock()
+	fcb := l.fcb
+	l.mu.Unlock()
+
+	l.header(frame, code, true, fcb)
+
+	err := l.transact(ctx, frame, Ack)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err == nil {
+		l.fcb = !l.fcb
+	} else {
+		l.linkReset = false
+	}
+
+	return err
+}
+
+// RequestLinkStatus asks the secondary station for its link status.
+func (l *LinkLayer) RequestLinkStatus(ctx context.Context) error {
+	l.sendMu.Lock()
+	defer l.sendMu.Unlock()
+
+	frame := NewFrame()
+	l.header(frame, RequestLinkStatus, false, false)
+
+	return l.transact(ctx, frame, LinkStatus)
+}
+
+// transact writes frame and waits for the secondary station to answer with
+// want, retransmitting it on each timeout. The caller holds l.sendMu.
+func (l *LinkLayer) transact(ctx context.Context, frame *Frame, want DataLinkSecondaryFunctionCode) error {
+	// Drop any late answer to an earlier frame.
+	select {
+	case <-l.replies:
+	default:
+	}
+
+	timeout := l.config.Timeout
+	if timeout <= 0 {
+		timeout = DefaultLinkTimeout
+	}
+
+	for range max(l.config.Retries, 0) + 1 {
+		err := l.write(frame)
+		if err != nil {
+			return err
+		}
+
+		timer := time.NewTimer(timeout)
+
+		select {
+		case code := <-l.replies:
+			timer.Stop()
+
+			switch code {
+			case want:
+				return nil
+			case Nack:
+				return fmt.Errorf("%w: %s", ErrLinkNack, frame.DataLink.Control.FunctionCode)
+			default:
+				return fmt.Errorf("%w: %s to %s", ErrLinkReply, code, frame.DataLink.Control.FunctionCode)
+			}
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+
+			return fmt.Errorf("waiting for data link response: %w", ctx.Err())
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrLinkTimeout, frame.DataLink.Control.FunctionCode)
+}
+
+// Receive processes a frame read from the connection and reports whether its
+// user data should be passed up to the transport layer. Responses from the
+// secondary station the pending primary transaction was sent to, addressed to
+// this station, complete that transaction; requests from
+// the primary station are answered, and a ConfirmedUserData frame that
+// repeats the last FCB is acknowledged again but not delivered.
+func (l *LinkLayer) Receive(frame *Frame) (bool, error) {
+	destination := frame.DataLink.Destination
+	if destination != l.config.LocalAddress && destination < 0xFFFD {
+		return false, nil
+	}
+
+	control := frame.DataLink.Control
+	if !control.Primary {
+		code, ok := control.FunctionCode.(DataLinkSecondaryFunctionCode)
+		if !ok {
+			return false, nil
+		}
+
+		// On a multi-drop line, another station's response doesn't answer
+		// ours, which went from LocalAddress to RemoteAddress.
+		if frame.DataLink.Source != l.config.RemoteAddress || destination != l.config.LocalAddress {
+			return false, nil
+		}
+
+		select {
+		case l.replies <- code:
+		default: // nothing is waiting, or an answer already is
+		}
+
+		return false, nil
+	}
+
+	code, ok := control.FunctionCode.(DataLinkPrimaryFunctionCode)
+	if !ok {
+		return false, nil
+	}
+
+	// Broadcasts are never answered.
+	broadcast := destination >= 0xFFFD
+
+	switch code {
+	case UnconfirmedUserData:
+		return true, nil
+	case ResetLinkStates:
+		l.mu.Lock()
+		l.remoteReset = true
+		l.expectedFCB = true
+		l.mu.Unlock()
+
+		return false, l.reply(frame, Ack, broadcast)
+	case TestLinkStates, ConfirmedUserData:
+		l.mu.Lock()
+		reset := l.remoteReset
+		duplicate := control.FrameCountBit != l.expectedFCB
+
+		if reset && !duplicate {
+			l.expectedFCB = !l.expectedFCB
+		}
+
+		l.mu.Unlock()
+
+		if !reset {
+			return false, l.reply(frame, Nack, broadcast)
+		}
+
+		return code == ConfirmedUserData && !duplicate, l.reply(frame, Ack, broadcast)
+	case RequestLinkStatus:
+		return false, l.reply(frame, LinkStatus, broadcast)
+	default:
+		return false, l.reply(frame, NotSupported, broadcast)
+	}
+}
+
+// reply answers a primary station frame from the secondary station.
+func (l *LinkLayer) reply(request *Frame, code DataLinkSecondaryFunctionCode, broadcast bool) error {
+	if broadcast {
+		return nil
+	}
+
+	frame := NewFrame()
+	frame.DataLink.Control = DataLinkControl{
+		Direction:    l.config.Master,
+		FunctionCode: code,
+	}
+	frame.DataLink.Destination = request.DataLink.Source
+	frame.DataLink.Source = l.config.LocalAddress
+
+	return l.write(frame)
+}
+
+// header fills in the data link header of a primary station frame.
+func (l *LinkLayer) header(frame *Frame, code DataLinkPrimaryFunctionCode, fcv, fcb bool) {
+	frame.DataLink.Control = DataLinkControl{
+		Direction:       l.config.Master,
+		Primary:         true,
+		FrameCountBit:   fcb,
+		FrameCountValid: fcv,
+		FunctionCode:    code,
+	}
+	frame.DataLink.Destination = l.config.RemoteAddress
+	frame.DataLink.Source = l.config.LocalAddress
+}
+
+func (l *LinkLayer) write(frame *Frame) error {
+	buf := gopacket.NewSerializeBuffer()
+
+	err := frame.SerializeTo(buf, gopacket.SerializeOptions{})
+	if err != nil {
+		return fmt.Errorf("encoding %s frame: %w", frame.DataLink.Control.FunctionCode, err)
+	}
+
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+
+	_, err = l.writer.Write(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("writing %s frame: %w", frame.DataLink.Control.FunctionCode, err)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package dnp3
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 )
@@ -38,6 +39,12 @@ func NewObjectHeaderFromBytes(data []byte) (*ObjectHeader, error) {
 }
 
 func (oh *ObjectHeader) DecodeFromBytes(data []byte) error {
+	return oh.decodeWithOptions(data, strictDecoding)
+}
+
+// decodeWithOptions is DecodeFromBytes with opts. Without
+// opts.Strict, the reserved qualifier bit is accepted in Reserved.
+func (oh *ObjectHeader) decodeWithOptions(data []byte, opts DecodeOptions) error {
 	if len(data) < 3 {
 		return fmt.Errorf("object headers are at 3 - 11 bytes, got %d", len(data))
 	}
@@ -79,7 +86,7 @@ func (oh *ObjectHeader) DecodeFromBytes(data []byte) error {
 	oh.size = consumed
 
 	if oh.Reserved {
-		return errors.New("first qualifier octet bit must be 0")
+		return opts.tolerate("application", data[:consumed], errors.New("first qualifier octet bit must be 0"))
 	}
 
 	return nil
@@ -141,6 +148,50 @@ Qualifier:
 	return headerString
 }
 
+// UnmarshalJSON rebuilds RangeField with the concrete type selected by
+// RangeSpecCode.
+func (oh *ObjectHeader) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Group           uint8           `json:"group"`
+		Variation       uint8           `json:"variation"`
+		Reserved        bool            `json:"reserved"`
+		PointPrefixCode PointPrefixCode `json:"point_prefix_code"`
+		RangeSpecCode   RangeSpecCode   `json:"range_spec_code"`
+		RangeField      json.RawMessage `json:"range_field"`
+	}
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal object header: %w", err)
+	}
+
+	ctor, err := rangeFieldConstructorFor(raw.RangeSpecCode)
+	if err != nil {
+		return err
+	}
+
+	rangeField := ctor()
+	if len(raw.RangeField) > 0 && string(raw.RangeField) != "null" {
+		err = json.Unmarshal(raw.RangeField, rangeField)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal range field: %w", err)
+		}
+	}
+
+	*oh = ObjectHeader{
+		Group:           raw.Group,
+		Variation:       raw.Variation,
+		objectType:      objectTypes[groupVariation{raw.Group, raw.Variation}],
+		Reserved:        raw.Reserved,
+		PointPrefixCode: raw.PointPrefixCode,
+		RangeSpecCode:   raw.RangeSpecCode,
+		RangeField:      rangeField,
+		size:            3 + rangeField.Size(),
+	}
+
+	return nil
+}
+
 func (oh *ObjectHeader) SizeOf() int {
 	return oh.size
 }
@@ -1,5 +1,11 @@
 package dnp3
 
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
 type groupVariation struct {
 	Group     uint8
 	Variation uint8
@@ -9,6 +15,71 @@ type objectType struct {
 	Description string
 	Constructor PointsConstructor `json:"-"`
 	Packer      PointsPacker      `json:"-"`
+	// Blank, if set, builds the points of blankPoints.
+	Blank PointsBlanker `json:"-"`
+}
+
+// NewPoints returns num zero-valued points of the type group/variation
+// decodes to, configured for prefixCode, ready to be filled in with the Set
+// methods and placed in a DataObject.
+func NewPoints(group, variation uint8, prefixCode PointPrefixCode, num int) ([]Point, error) {
+	def, ok := objectTypes[groupVariation{group, variation}]
+	if !ok {
+		return nil, fmt.Errorf("unsupported group/variation: %d/%d", group, variation)
+	}
+
+	return def.blankPoints(num, prefixCode)
+}
+
+// maxPointWidth bounds the on-wire size of a single point, including its
+// prefix, for any supported group/variation.
+const maxPointWidth = 4 + 255
+
+// blankPoints returns num points configured for this group/variation and
+// prefix code (layout, prefix widths, numeric encoding) with zero values, by
+// decoding an all-zero buffer unless the type has a Blank constructor.
+// Callers then fill in the fields.
+func (ot *objectType) blankPoints(num int, prefCode PointPrefixCode) ([]Point, error) {
+	if ot.Blank != nil {
+		return ot.Blank(num, prefCode)
+	}
+
+	if ot.Constructor == nil {
+		return nil, errors.New("no constructor")
+	}
+
+	prefSize := prefCode.GetPointPrefixSize()
+
+	// Decode one point first to learn how many bytes num points need.
+	_, width, err := ot.Constructor(make([]byte, maxPointWidth), 1, prefSize, prefCode)
+	if err != nil {
+		return nil, err
+	}
+
+	points, _, err := ot.Constructor(make([]byte, num*width), num, prefSize, prefCode)
+	if err != nil {
+		return nil, fmt.Errorf("can't create %d blank points: %w", num, err)
+	}
+
+	return points, nil
+}
+
+// init adds the groups whose variations are generated rather than listed:
+// the device attributes (Group 0), where the variation names the attribute,
+// and the octet string and virtual terminal groups (110-113), where it is the
+// length of the value.
+//
+//nolint:gochecknoinits // the 5 x 255 generated variations can't be map literal entries.
+func init() {
+	for variation := range uint8(math.MaxUint8) {
+		variation++
+
+		objectTypes[groupVariation{0, variation}] = deviceAttributeType(variation)
+
+		for group := range octetStringGroups {
+			objectTypes[groupVariation{group, variation}] = octetStringType(group, variation)
+		}
+	}
 }
 
 var objectTypes = map[groupVariation]*objectType{
@@ -108,15 +179,19 @@ var objectTypes = map[groupVariation]*objectType{
 	{12, 0}: {Description: "(Command) Binary Output Command - Any Variations"},
 	{12, 1}: {
 		Description: "(Command) Binary Output Command - Control Relay Output Block",
-		Constructor: makeBytesConstructor(layoutValue, 11),
+		Constructor: newPointsCROB,
 		Packer:      packPointsBytes,
 	},
 	{12, 2}: {
 		Description: "(Command) Binary Output Command - Pattern Control Block",
-		Constructor: makeBytesConstructor(layoutValue, 11),
+		Constructor: newPointsCROB,
 		Packer:      packPointsBytes,
 	},
-	{12, 3}: {Description: "(Command) Binary Output Command - Pattern Mask"},
+	{12, 3}: {
+		Description: "(Command) Binary Output Command - Pattern Mask",
+		Constructor: newPointsBit,
+		Packer:      packerPointsBit,
+	},
 
 	// Binary Output Command Event
 	{13, 0}: {Description: "(Event) Binary Output Command Event - Any Variations"},
@@ -135,22 +210,22 @@ var objectTypes = map[groupVariation]*objectType{
 	{20, 0}: {Description: "(Static) Counter - Any Variations"},
 	{20, 1}: {
 		Description: "(Static) Counter - 32-bit with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 5),
+		Constructor: makeNumericConstructor(layoutFlags, 5, NumericUint32),
 		Packer:      packPointsBytes,
 	},
 	{20, 2}: {
 		Description: "(Static) Counter - 16-bit with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 3),
+		Constructor: makeNumericConstructor(layoutFlags, 3, NumericUint16),
 		Packer:      packPointsBytes,
 	},
 	{20, 5}: {
 		Description: "(Static) Counter - 32-bit w/o Flag",
-		Constructor: makeBytesConstructor(layoutValue, 4),
+		Constructor: makeNumericConstructor(layoutValue, 4, NumericUint32),
 		Packer:      packPointsBytes,
 	},
 	{20, 6}: {
 		Description: "(Static) Counter - 16-bit w/o Flag",
-		Constructor: makeBytesConstructor(layoutValue, 2),
+		Constructor: makeNumericConstructor(layoutValue, 2, NumericUint16),
 		Packer:      packPointsBytes,
 	},
 
@@ -158,32 +233,32 @@ var objectTypes = map[groupVariation]*objectType{
 	{21, 0}: {Description: "(Static) Frozen Counter - Any Variations"},
 	{21, 1}: {
 		Description: "(Static) Frozen Counter - 32-bit with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 5),
+		Constructor: makeNumericConstructor(layoutFlags, 5, NumericUint32),
 		Packer:      packPointsBytes,
 	},
 	{21, 2}: {
 		Description: "(Static) Frozen Counter - 16-bit with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 3),
+		Constructor: makeNumericConstructor(layoutFlags, 3, NumericUint16),
 		Packer:      packPointsBytes,
 	},
 	{21, 5}: {
 		Description: "(Static) Frozen Counter - 32-bit with Flag and Time",
-		Constructor: makeBytesConstructor(layoutFlagsAbsTime, 11),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 11, NumericUint32),
 		Packer:      packPointsBytes,
 	},
 	{21, 6}: {
 		Description: "(Static) Frozen Counter - 16-bit with Flag and Time",
-		Constructor: makeBytesConstructor(layoutFlagsAbsTime, 9),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 9, NumericUint16),
 		Packer:      packPointsBytes,
 	},
 	{21, 9}: {
 		Description: "(Static) Frozen Counter - 32-bit w/o Flag",
-		Constructor: makeBytesConstructor(layoutValue, 4),
+		Constructor: makeNumericConstructor(layoutValue, 4, NumericUint32),
 		Packer:      packPointsBytes,
 	},
 	{21, 10}: {
 		Description: "(Static) Frozen Counter - 16-bit w/o Flag",
-		Constructor: makeBytesConstructor(layoutValue, 2),
+		Constructor: makeNumericConstructor(layoutValue, 2, NumericUint16),
 		Packer:      packPointsBytes,
 	},
 
@@ -191,22 +266,22 @@ var objectTypes = map[groupVariation]*objectType{
 	{22, 0}: {Description: "(Event) Counter Event - Any Variations"},
 	{22, 1}: {
 		Description: "(Event) Counter Event - 32-bit with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 5),
+		Constructor: makeNumericConstructor(layoutFlags, 5, NumericUint32),
 		Packer:      packPointsBytes,
 	},
 	{22, 2}: {
 		Description: "(Event) Counter Event - 16-bit with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 3),
+		Constructor: makeNumericConstructor(layoutFlags, 3, NumericUint16),
 		Packer:      packPointsBytes,
 	},
 	{22, 5}: {
 		Description: "(Event) Counter Event - 32-bit with Flag and Time",
-		Constructor: makeBytesConstructor(layoutFlagsAbsTime, 11),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 11, NumericUint32),
 		Packer:      packPointsBytes,
 	},
 	{22, 6}: {
 		Description: "(Event) Counter Event - 16-bit with Flag and Time",
-		Constructor: makeBytesConstructor(layoutFlagsAbsTime, 9),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 9, NumericUint16),
 		Packer:      packPointsBytes,
 	},
 
@@ -214,22 +289,22 @@ var objectTypes = map[groupVariation]*objectType{
 	{23, 0}: {Description: "(Event) Frozen Counter Event - Any Variations"},
 	{23, 1}: {
 		Description: "(Event) Frozen Counter Event - 32-bit with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 5),
+		Constructor: makeNumericConstructor(layoutFlags, 5, NumericUint32),
 		Packer:      packPointsBytes,
 	},
 	{23, 2}: {
 		Description: "(Event) Frozen Counter Event - 16-bit with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 2),
+		Constructor: makeNumericConstructor(layoutFlags, 3, NumericUint16),
 		Packer:      packPointsBytes,
 	},
 	{23, 5}: {
 		Description: "(Event) Frozen Counter Event - 32-bit with Flag and Time",
-		Constructor: makeBytesConstructor(layoutFlagsAbsTime, 11),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 11, NumericUint32),
 		Packer:      packPointsBytes,
 	},
 	{23, 6}: {
 		Description: "(Event) Frozen Counter Event - 16-bit with Flag and Time",
-		Constructor: makeBytesConstructor(layoutFlagsAbsTime, 9),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 9, NumericUint16),
 		Packer:      packPointsBytes,
 	},
 
@@ -237,32 +312,32 @@ var objectTypes = map[groupVariation]*objectType{
 	{30, 0}: {Description: "(Static) Analog Input - Any Variations"},
 	{30, 1}: {
 		Description: "(Static) Analog Input - 32-bit with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 5),
+		Constructor: makeNumericConstructor(layoutFlags, 5, NumericInt32),
 		Packer:      packPointsBytes,
 	},
 	{30, 2}: {
 		Description: "(Static) Analog Input - 16-bit with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 3),
+		Constructor: makeNumericConstructor(layoutFlags, 3, NumericInt16),
 		Packer:      packPointsBytes,
 	},
 	{30, 3}: {
 		Description: "(Static) Analog Input - 32-bit w/o Flag",
-		Constructor: makeBytesConstructor(layoutValue, 4),
+		Constructor: makeNumericConstructor(layoutValue, 4, NumericInt32),
 		Packer:      packPointsBytes,
 	},
 	{30, 4}: {
 		Description: "(Static) Analog Input - 16-bit w/o Flag",
-		Constructor: makeBytesConstructor(layoutValue, 2),
+		Constructor: makeNumericConstructor(layoutValue, 2, NumericInt16),
 		Packer:      packPointsBytes,
 	},
 	{30, 5}: {
 		Description: "(Static) Analog Input - Single-prec. FP with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 5),
+		Constructor: makeNumericConstructor(layoutFlags, 5, NumericFloat32),
 		Packer:      packPointsBytes,
 	},
 	{30, 6}: {
 		Description: "(Static) Analog Input - Double-prec. FP with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 9),
+		Constructor: makeNumericConstructor(layoutFlags, 9, NumericFloat64),
 		Packer:      packPointsBytes,
 	},
 
@@ -270,42 +345,42 @@ var objectTypes = map[groupVariation]*objectType{
 	{31, 0}: {Description: "(Static) Frozen Analog Input - Any Variations"},
 	{31, 1}: {
 		Description: "(Static) Frozen Analog Input - 32-bit with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 5),
+		Constructor: makeNumericConstructor(layoutFlags, 5, NumericInt32),
 		Packer:      packPointsBytes,
 	},
 	{31, 2}: {
 		Description: "(Static) Frozen Analog Input - 16-bit with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 3),
+		Constructor: makeNumericConstructor(layoutFlags, 3, NumericInt16),
 		Packer:      packPointsBytes,
 	},
 	{31, 3}: {
 		Description: "(Static) Frozen Analog Input - 32-bit with Time-of-Freeze",
-		Constructor: makeBytesConstructor(layoutValue, 11),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 11, NumericInt32),
 		Packer:      packPointsBytes,
 	},
 	{31, 4}: {
 		Description: "(Static) Frozen Analog Input - 16-bit with Time-of-Freeze",
-		Constructor: makeBytesConstructor(layoutValue, 9),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 9, NumericInt16),
 		Packer:      packPointsBytes,
 	},
 	{31, 5}: {
 		Description: "(Static) Frozen Analog Input - 32-bit w/o Flag",
-		Constructor: makeBytesConstructor(layoutValue, 4),
+		Constructor: makeNumericConstructor(layoutValue, 4, NumericInt32),
 		Packer:      packPointsBytes,
 	},
 	{31, 6}: {
 		Description: "(Static) Frozen Analog Input - 16-bit w/o Flag",
-		Constructor: makeBytesConstructor(layoutValue, 2),
+		Constructor: makeNumericConstructor(layoutValue, 2, NumericInt16),
 		Packer:      packPointsBytes,
 	},
 	{31, 7}: {
 		Description: "(Static) Frozen Analog Input - Single-prec. FP with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 5),
+		Constructor: makeNumericConstructor(layoutFlags, 5, NumericFloat32),
 		Packer:      packPointsBytes,
 	},
 	{31, 8}: {
 		Description: "(Static) Frozen Analog Input - Double-prec. FP with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 9),
+		Constructor: makeNumericConstructor(layoutFlags, 9, NumericFloat64),
 		Packer:      packPointsBytes,
 	},
 
@@ -313,42 +388,42 @@ var objectTypes = map[groupVariation]*objectType{
 	{32, 0}: {Description: "(Event) Analog Input Event - Any Variations"},
 	{32, 1}: {
 		Description: "(Event) Analog Input Event - 32-bit",
-		Constructor: makeBytesConstructor(layoutValue, 5),
+		Constructor: makeNumericConstructor(layoutFlags, 5, NumericInt32),
 		Packer:      packPointsBytes,
 	},
 	{32, 2}: {
 		Description: "(Event) Analog Input Event - 16-bit",
-		Constructor: makeBytesConstructor(layoutValue, 3),
+		Constructor: makeNumericConstructor(layoutFlags, 3, NumericInt16),
 		Packer:      packPointsBytes,
 	},
 	{32, 3}: {
 		Description: "(Event) Analog Input Event - 32-bit with Time",
-		Constructor: makeBytesConstructor(layoutValue, 11),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 11, NumericInt32),
 		Packer:      packPointsBytes,
 	},
 	{32, 4}: {
 		Description: "(Event) Analog Input Event - 16-bit with Time",
-		Constructor: makeBytesConstructor(layoutValue, 9),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 9, NumericInt16),
 		Packer:      packPointsBytes,
 	},
 	{32, 5}: {
 		Description: "(Event) Analog Input Event - Single-prec. FP",
-		Constructor: makeBytesConstructor(layoutValue, 5),
+		Constructor: makeNumericConstructor(layoutFlags, 5, NumericFloat32),
 		Packer:      packPointsBytes,
 	},
 	{32, 6}: {
 		Description: "(Event) Analog Input Event - Double-prec. FP",
-		Constructor: makeBytesConstructor(layoutValue, 9),
+		Constructor: makeNumericConstructor(layoutFlags, 9, NumericFloat64),
 		Packer:      packPointsBytes,
 	},
 	{32, 7}: {
 		Description: "(Event) Analog Input Event - Single-prec. FP with Time",
-		Constructor: makeBytesConstructor(layoutValue, 11),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 11, NumericFloat32),
 		Packer:      packPointsBytes,
 	},
 	{32, 8}: {
 		Description: "(Event) Analog Input Event - Double-prec. FP with Time",
-		Constructor: makeBytesConstructor(layoutValue, 15),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 15, NumericFloat64),
 		Packer:      packPointsBytes,
 	},
 
@@ -356,42 +431,42 @@ var objectTypes = map[groupVariation]*objectType{
 	{33, 0}: {Description: "(Event) Frozen Analog Input Event - Any Variations"},
 	{33, 1}: {
 		Description: "(Event) Frozen Analog Input Event - 32-bit",
-		Constructor: makeBytesConstructor(layoutValue, 5),
+		Constructor: makeNumericConstructor(layoutFlags, 5, NumericInt32),
 		Packer:      packPointsBytes,
 	},
 	{33, 2}: {
 		Description: "(Event) Frozen Analog Input Event - 16-bit",
-		Constructor: makeBytesConstructor(layoutValue, 3),
+		Constructor: makeNumericConstructor(layoutFlags, 3, NumericInt16),
 		Packer:      packPointsBytes,
 	},
 	{33, 3}: {
 		Description: "(Event) Frozen Analog Input Event - 32-bit with Time",
-		Constructor: makeBytesConstructor(layoutValue, 11),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 11, NumericInt32),
 		Packer:      packPointsBytes,
 	},
 	{33, 4}: {
 		Description: "(Event) Frozen Analog Input Event - 16-bit with Time",
-		Constructor: makeBytesConstructor(layoutValue, 9),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 9, NumericInt16),
 		Packer:      packPointsBytes,
 	},
 	{33, 5}: {
 		Description: "(Event) Frozen Analog Input Event - Single-prec. FP",
-		Constructor: makeBytesConstructor(layoutValue, 5),
+		Constructor: makeNumericConstructor(layoutFlags, 5, NumericFloat32),
 		Packer:      packPointsBytes,
 	},
 	{33, 6}: {
 		Description: "(Event) Frozen Analog Input Event - Double-prec. FP",
-		Constructor: makeBytesConstructor(layoutValue, 9),
+		Constructor: makeNumericConstructor(layoutFlags, 9, NumericFloat64),
 		Packer:      packPointsBytes,
 	},
 	{33, 7}: {
 		Description: "(Event) Frozen Analog Input Event - Single-prec. FP with Time",
-		Constructor: makeBytesConstructor(layoutValue, 11),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 11, NumericFloat32),
 		Packer:      packPointsBytes,
 	},
 	{33, 8}: {
 		Description: "(Event) Frozen Analog Input Event - Double-prec. FP with Time",
-		Constructor: makeBytesConstructor(layoutValue, 15),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 15, NumericFloat64),
 		Packer:      packPointsBytes,
 	},
 
@@ -399,17 +474,17 @@ var objectTypes = map[groupVariation]*objectType{
 	{34, 0}: {Description: "(Static) Analog Input Deadband - Any Variations"},
 	{34, 1}: {
 		Description: "(Static) Analog Input Deadband - 16-bit",
-		Constructor: makeBytesConstructor(layoutValue, 2),
+		Constructor: makeNumericConstructor(layoutValue, 2, NumericUint16),
 		Packer:      packPointsBytes,
 	},
 	{34, 2}: {
 		Description: "(Static) Analog Input Deadband - 32-bit",
-		Constructor: makeBytesConstructor(layoutValue, 4),
+		Constructor: makeNumericConstructor(layoutValue, 4, NumericUint32),
 		Packer:      packPointsBytes,
 	},
 	{34, 3}: {
 		Description: "(Static) Analog Input Deadband - Single-prec. FP",
-		Constructor: makeBytesConstructor(layoutValue, 4),
+		Constructor: makeNumericConstructor(layoutValue, 4, NumericFloat32),
 		Packer:      packPointsBytes,
 	},
 
@@ -417,22 +492,22 @@ var objectTypes = map[groupVariation]*objectType{
 	{40, 0}: {Description: "(Static) Analog Output Status - Any Variations"},
 	{40, 1}: {
 		Description: "(Static) Analog Output Status - 32-bit with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 5),
+		Constructor: makeNumericConstructor(layoutFlags, 5, NumericInt32),
 		Packer:      packPointsBytes,
 	},
 	{40, 2}: {
 		Description: "(Static) Analog Output Status - 16-bit with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 3),
+		Constructor: makeNumericConstructor(layoutFlags, 3, NumericInt16),
 		Packer:      packPointsBytes,
 	},
 	{40, 3}: {
 		Description: "(Static) Analog Output Status - Single-prec. FP with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 5),
+		Constructor: makeNumericConstructor(layoutFlags, 5, NumericFloat32),
 		Packer:      packPointsBytes,
 	},
 	{40, 4}: {
 		Description: "(Static) Analog Output Status - Double-prec. FP with Flag",
-		Constructor: makeBytesConstructor(layoutFlags, 9),
+		Constructor: makeNumericConstructor(layoutFlags, 9, NumericFloat64),
 		Packer:      packPointsBytes,
 	},
 
@@ -440,22 +515,22 @@ var objectTypes = map[groupVariation]*objectType{
 	{41, 0}: {Description: "(Command) Analog Output Command - Any Variations"},
 	{41, 1}: {
 		Description: "(Command) Analog Output Command - 32-bit",
-		Constructor: makeBytesConstructor(layoutValue, 5),
+		Constructor: makeAnalogOutputConstructor(NumericInt32),
 		Packer:      packPointsBytes,
 	},
 	{41, 2}: {
 		Description: "(Command) Analog Output Command - 16-bit",
-		Constructor: makeBytesConstructor(layoutValue, 3),
+		Constructor: makeAnalogOutputConstructor(NumericInt16),
 		Packer:      packPointsBytes,
 	},
 	{41, 3}: {
 		Description: "(Command) Analog Output Command - Single-prec. FP",
-		Constructor: makeBytesConstructor(layoutValue, 5),
+		Constructor: makeAnalogOutputConstructor(NumericFloat32),
 		Packer:      packPointsBytes,
 	},
 	{41, 4}: {
 		Description: "(Command) Analog Output Command - Double-prec. FP",
-		Constructor: makeBytesConstructor(layoutValue, 9),
+		Constructor: makeAnalogOutputConstructor(NumericFloat64),
 		Packer:      packPointsBytes,
 	},
 
@@ -463,42 +538,42 @@ var objectTypes = map[groupVariation]*objectType{
 	{42, 0}: {Description: "(Event) Analog Output Event - Any Variations"},
 	{42, 1}: {
 		Description: "(Event) Analog Output Event - 32-bit",
-		Constructor: makeBytesConstructor(layoutValue, 5),
+		Constructor: makeNumericConstructor(layoutFlags, 5, NumericInt32),
 		Packer:      packPointsBytes,
 	},
 	{42, 2}: {
 		Description: "(Event) Analog Output Event - 16-bit",
-		Constructor: makeBytesConstructor(layoutValue, 3),
+		Constructor: makeNumericConstructor(layoutFlags, 3, NumericInt16),
 		Packer:      packPointsBytes,
 	},
 	{42, 3}: {
 		Description: "(Event) Analog Output Event - 32-bit with Time",
-		Constructor: makeBytesConstructor(layoutValue, 11),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 11, NumericInt32),
 		Packer:      packPointsBytes,
 	},
 	{42, 4}: {
 		Description: "(Event) Analog Output Event - 16-bit with Time",
-		Constructor: makeBytesConstructor(layoutValue, 9),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 9, NumericInt16),
 		Packer:      packPointsBytes,
 	},
 	{42, 5}: {
 		Description: "(Event) Analog Output Event - Single-prec. FP",
-		Constructor: makeBytesConstructor(layoutValue, 5),
+		Constructor: makeNumericConstructor(layoutFlags, 5, NumericFloat32),
 		Packer:      packPointsBytes,
 	},
 	{42, 6}: {
 		Description: "(Event) Analog Output Event - Double-prec. FP",
-		Constructor: makeBytesConstructor(layoutValue, 9),
+		Constructor: makeNumericConstructor(layoutFlags, 9, NumericFloat64),
 		Packer:      packPointsBytes,
 	},
 	{42, 7}: {
 		Description: "(Event) Analog Output Event - Single-prec. FP with Time",
-		Constructor: makeBytesConstructor(layoutValue, 11),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 11, NumericFloat32),
 		Packer:      packPointsBytes,
 	},
 	{42, 8}: {
 		Description: "(Event) Analog Output Event - Double-prec. FP with Time",
-		Constructor: makeBytesConstructor(layoutValue, 15),
+		Constructor: makeNumericConstructor(layoutFlagsValueAbsTime, 15, NumericFloat64),
 		Packer:      packPointsBytes,
 	},
 
@@ -506,42 +581,42 @@ var objectTypes = map[groupVariation]*objectType{
 	{43, 0}: {Description: "(Event) Analog Output Command Event - Any Variations"},
 	{43, 1}: {
 		Description: "(Event) Analog Output Command Event - 32-bit",
-		Constructor: makeBytesConstructor(layoutValue, 5),
+		Constructor: makeNumericConstructorAt(layoutValue, 5, NumericInt32, 1),
 		Packer:      packPointsBytes,
 	},
 	{43, 2}: {
 		Description: "(Event) Analog Output Command Event - 16-bit",
-		Constructor: makeBytesConstructor(layoutValue, 3),
+		Constructor: makeNumericConstructorAt(layoutValue, 3, NumericInt16, 1),
 		Packer:      packPointsBytes,
 	},
 	{43, 3}: {
 		Description: "(Event) Analog Output Command Event - 32-bit with Time",
-		Constructor: makeBytesConstructor(layoutValue, 11),
+		Constructor: makeNumericConstructorAt(layoutValue, 11, NumericInt32, 1),
 		Packer:      packPointsBytes,
 	},
 	{43, 4}: {
 		Description: "(Event) Analog Output Command Event - 16-bit with Time",
-		Constructor: makeBytesConstructor(layoutValue, 9),
+		Constructor: makeNumericConstructorAt(layoutValue, 9, NumericInt16, 1),
 		Packer:      packPointsBytes,
 	},
 	{43, 5}: {
 		Description: "(Event) Analog Output Command Event - Single-prec. FP",
-		Constructor: makeBytesConstructor(layoutValue, 5),
+		Constructor: makeNumericConstructorAt(layoutValue, 5, NumericFloat32, 1),
 		Packer:      packPointsBytes,
 	},
 	{43, 6}: {
 		Description: "(Event) Analog Output Command Event - Double-prec. FP",
-		Constructor: makeBytesConstructor(layoutValue, 9),
+		Constructor: makeNumericConstructorAt(layoutValue, 9, NumericFloat64, 1),
 		Packer:      packPointsBytes,
 	},
 	{43, 7}: {
 		Description: "(Event) Analog Output Command Event - Single-prec. FP with Time",
-		Constructor: makeBytesConstructor(layoutValue, 11),
+		Constructor: makeNumericConstructorAt(layoutValue, 11, NumericFloat32, 1),
 		Packer:      packPointsBytes,
 	},
 	{43, 8}: {
 		Description: "(Event) Analog Output Command Event - Double-prec. FP with Time",
-		Constructor: makeBytesConstructor(layoutValue, 15),
+		Constructor: makeNumericConstructorAt(layoutValue, 15, NumericFloat64, 1),
 		Packer:      packPointsBytes,
 	},
 
@@ -613,10 +688,191 @@ var objectTypes = map[groupVariation]*objectType{
 		Packer:      packNoPoints,
 	},
 
+	// File Control
+	{70, 2}: {
+		Description: "(Command) File Authentication",
+		Constructor: makeSizedConstructor[FileAuthentication](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[FileAuthentication](),
+	},
+	{70, 3}: {
+		Description: "(Command) File Command",
+		Constructor: makeSizedConstructor[FileCommand](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[FileCommand](),
+	},
+	{70, 4}: {
+		Description: "(Command) File Command Status",
+		Constructor: makeSizedConstructor[FileCommandStatus](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[FileCommandStatus](),
+	},
+	{70, 5}: {
+		Description: "(Command) File Transport",
+		Constructor: makeSizedConstructor[FileTransport](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[FileTransport](),
+	},
+	{70, 6}: {
+		Description: "(Command) File Transport Status",
+		Constructor: makeSizedConstructor[FileTransportStatus](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[FileTransportStatus](),
+	},
+	{70, 7}: {
+		Description: "(Info) File Descriptor",
+		Constructor: makeSizedConstructor[FileDescriptor](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[FileDescriptor](),
+	},
+	{70, 8}: {
+		Description: "(Info) File Specification String",
+		Constructor: makeSizedConstructor[FileSpecification](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[FileSpecification](),
+	},
+
 	// Internal Indications
 	{80, 1}: {
 		Description: "(Info) Internal Indications - Packed Format",
 		Constructor: makeBytesConstructor(layoutValue, 1),
 		Packer:      packPointsBytes,
 	},
+
+	// Data Sets
+	{85, 0}: {Description: "(Info) Data Set Prototype - Any Variations"},
+	{85, 1}: {
+		Description: "(Info) Data Set Prototype - With UUID",
+		Constructor: makeSizedConstructor[DataSetPrototype](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[DataSetPrototype](),
+	},
+	{86, 0}: {Description: "(Info) Data Set Descriptor - Any Variations"},
+	{86, 1}: {
+		Description: "(Info) Data Set Descriptor - Data Set Contents",
+		Constructor: makeSizedConstructor[DataSetDescriptor](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[DataSetDescriptor](),
+	},
+	{86, 2}: {
+		Description: "(Info) Data Set Descriptor - Characteristics",
+		Constructor: makeBytesConstructor(layoutValue, 1),
+		Packer:      packPointsBytes,
+	},
+	{86, 3}: {
+		Description: "(Info) Data Set Descriptor - Point Index Attributes",
+		Constructor: makeSizedConstructor[DataSetPointIndexes](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[DataSetPointIndexes](),
+	},
+	{87, 0}: {Description: "(Static) Data Set - Any Variations"},
+	{87, 1}: {
+		Description: "(Static) Data Set - Present Value",
+		Constructor: makeSizedConstructor[DataSetValue](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[DataSetValue](),
+	},
+	{88, 0}: {Description: "(Event) Data Set Event - Any Variations"},
+	{88, 1}: {
+		Description: "(Event) Data Set Event - Snapshot",
+		Constructor: makeSizedConstructor[DataSetEvent](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[DataSetEvent](),
+	},
+
+	// Octet String and Virtual Terminal, Var 1-255 are added by init
+	{110, 0}: {Description: "(Static) Octet String - Any Length"},
+	{111, 0}: {Description: "(Event) Octet String Event - Any Length"},
+	{112, 0}: {Description: "(Command) Virtual Terminal Output Block - Any Length"},
+	{113, 0}: {Description: "(Event) Virtual Terminal Event Data - Any Length"},
+
+	// Authentication
+	{120, 1}: {
+		Description: "(Command) Authentication Challenge",
+		Constructor: makeSizedConstructor[AuthChallenge](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[AuthChallenge](),
+	},
+	{120, 2}: {
+		Description: "(Command) Authentication Reply",
+		Constructor: makeSizedConstructor[AuthReply](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[AuthReply](),
+	},
+	{120, 3}: {
+		Description: "(Command) Authentication Aggressive Mode Request",
+		Constructor: makeFixedAuthConstructor[AuthAggressiveRequest](6),
+		Packer:      packPointsBytes,
+	},
+	{120, 4}: {
+		Description: "(Command) Authentication Session Key Status Request",
+		Constructor: makeFixedAuthConstructor[AuthKeyStatusRequest](2),
+		Packer:      packPointsBytes,
+	},
+	{120, 5}: {
+		Description: "(Command) Authentication Session Key Status",
+		Constructor: makeSizedConstructor[AuthKeyStatus](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[AuthKeyStatus](),
+	},
+	{120, 6}: {
+		Description: "(Command) Authentication Session Key Change",
+		Constructor: makeSizedConstructor[AuthKeyChange](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[AuthKeyChange](),
+	},
+	{120, 7}: {
+		Description: "(Command) Authentication Error",
+		Constructor: makeSizedConstructor[AuthError](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[AuthError](),
+	},
+	{120, 8}: {
+		Description: "(Command) Authentication User Certificate",
+		Constructor: makeSizedConstructor[AuthUserCertificate](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[AuthUserCertificate](),
+	},
+	{120, 9}: {
+		Description: "(Command) Authentication Message Authentication Code",
+		Constructor: makeSizedConstructor[AuthMAC](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[AuthMAC](),
+	},
+	{120, 10}: {
+		Description: "(Command) Authentication User Status Change",
+		Constructor: makeSizedConstructor[AuthUserStatusChange](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[AuthUserStatusChange](),
+	},
+	{120, 11}: {
+		Description: "(Command) Authentication Update Key Change Request",
+		Constructor: makeSizedConstructor[AuthUpdateKeyChangeRequest](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[AuthUpdateKeyChangeRequest](),
+	},
+	{120, 12}: {
+		Description: "(Command) Authentication Update Key Change Reply",
+		Constructor: makeSizedConstructor[AuthUpdateKeyChangeReply](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[AuthUpdateKeyChangeReply](),
+	},
+	{120, 13}: {
+		Description: "(Command) Authentication Update Key Change",
+		Constructor: makeSizedConstructor[AuthUpdateKeyChange](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[AuthUpdateKeyChange](),
+	},
+	{120, 14}: {
+		Description: "(Command) Authentication Update Key Change Signature",
+		Constructor: makeSizedConstructor[AuthUpdateKeySignature](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[AuthUpdateKeySignature](),
+	},
+	{120, 15}: {
+		Description: "(Command) Authentication Update Key Change Confirmation",
+		Constructor: makeSizedConstructor[AuthUpdateKeyConfirmation](),
+		Packer:      packPointsBytes,
+		Blank:       makeSizedBlank[AuthUpdateKeyConfirmation](),
+	},
 }
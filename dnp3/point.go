@@ -48,6 +48,10 @@ type PointsConstructor func([]byte, int, int, PointPrefixCode) ([]Point, int, er
 
 type PointsPacker func([]Point) ([]byte, error)
 
+// PointsBlanker returns a number of zero-valued points for a point prefix code,
+// for the object types that can't build them by decoding zero bytes.
+type PointsBlanker func(int, PointPrefixCode) ([]Point, error)
+
 // Sentinel errors for unsupported Point field access.
 var (
 	ErrNoIndex   = errors.New("point does not have an index")
@@ -0,0 +1,284 @@
+package dnp3
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PointDataTypeAnalogOutput identifies an analog output block point.
+const PointDataTypeAnalogOutput PointDataType = "analog-output"
+
+// AnalogOutputBlock is an Analog Output Block (Group 41 Var 1-4): a setpoint
+// followed by the status the outstation echoes back. The variation sets how
+// the setpoint is encoded (see NumericEncoding); Value holds it as a float64,
+// which every encoding fits in exactly.
+type AnalogOutputBlock struct {
+	Index     *int `json:"index,omitempty"`
+	indexSize int
+	encoding  NumericEncoding
+	Value     float64       `json:"value"`
+	Status    CommandStatus `json:"status"`
+}
+
+func (p *AnalogOutputBlock) DataType() PointDataType { return PointDataTypeAnalogOutput }
+
+// NumericEncoding returns the encoding of the setpoint: Int32, Int16, Float32
+// or Float64 for Var 1 to 4.
+func (p *AnalogOutputBlock) NumericEncoding() NumericEncoding {
+	return p.encoding
+}
+
+func (p *AnalogOutputBlock) DecodeFromBytes(data []byte, prefSize int) error {
+	width := p.encoding.Size() + 1
+	if width == 1 {
+		return errors.New("analog output block has no numeric encoding")
+	}
+
+	if len(data) != prefSize+width {
+		return fmt.Errorf("analog output block requires %d bytes, got %d", prefSize+width, len(data))
+	}
+
+	if prefSize > 0 {
+		index, err := prefixToInt(data[:prefSize])
+		if err != nil {
+			return fmt.Errorf("could not decode index prefix: %w", err)
+		}
+
+		p.Index = &index
+		p.indexSize = prefSize
+	}
+
+	data = data[prefSize:]
+
+	switch p.encoding {
+	case NumericInt32:
+		p.Value = float64(int32(binary.LittleEndian.Uint32(data))) //nolint:gosec // two's complement reinterpretation
+	case NumericInt16:
+		p.Value = float64(int16(binary.LittleEndian.Uint16(data))) //nolint:gosec // two's complement reinterpretation
+	case NumericFloat32:
+		p.Value = float64(math.Float32frombits(binary.LittleEndian.Uint32(data)))
+	case NumericFloat64:
+		p.Value = math.Float64frombits(binary.LittleEndian.Uint64(data))
+	case NumericNone, NumericUint16, NumericUint32:
+		return fmt.Errorf("analog output blocks can't use %s", p.encoding)
+	default:
+		return fmt.Errorf("unexpected numeric encoding %d", p.encoding)
+	}
+
+	// The reserved top bit is kept as received; Validate reports it.
+	p.Status = CommandStatus(data[width-1])
+
+	return nil
+}
+
+func (p *AnalogOutputBlock) SerializeTo() ([]byte, error) {
+	var output []byte
+
+	if p.indexSize > 0 {
+		indexBytes, err := intToPrefix(*p.Index, p.indexSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode index: %w", err)
+		}
+
+		output = append(output, indexBytes...)
+	}
+
+	value, err := encodeNumeric(p.encoding, p.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode setpoint: %w", err)
+	}
+
+	if p.Status > 0b01111111 {
+		return nil, fmt.Errorf("analog output block status %d exceeds 7 bits", p.Status)
+	}
+
+	output = append(output, value...)
+
+	return append(output, byte(p.Status)), nil
+}
+
+func (p *AnalogOutputBlock) String() string {
+	var parts []string
+
+	if p.indexSize > 0 {
+		parts = append(parts, fmt.Sprintf("Index: %d", *p.Index))
+	}
+
+	parts = append(parts,
+		fmt.Sprintf("Value : %s (%s)", strconv.FormatFloat(p.Value, 'g', -1, 64), p.encoding),
+		fmt.Sprintf("Status: (%d) %s", p.Status, p.Status),
+	)
+
+	return strings.Join(parts, "\n")
+}
+
+func (p *AnalogOutputBlock) Fields() PointFields {
+	return PointFields{
+		Index: p.indexSize > 0,
+		Value: true,
+	}
+}
+
+// --- Get/Set methods ---
+
+func (p *AnalogOutputBlock) GetIndex() (int, error) {
+	if p.indexSize == 0 {
+		return 0, ErrNoIndex
+	}
+
+	return *p.Index, nil
+}
+
+func (p *AnalogOutputBlock) SetIndex(value int) error {
+	return setIndex(&p.Index, &p.indexSize, value)
+}
+
+func (p *AnalogOutputBlock) GetFlags() (PointFlags, error)     { return PointFlags{}, ErrNoFlags }
+func (p *AnalogOutputBlock) SetFlags(PointFlags) error         { return ErrNoFlags }
+func (p *AnalogOutputBlock) GetAbsTime() (AbsoluteTime, error) { return AbsoluteTime{}, ErrNoAbsTime }
+func (p *AnalogOutputBlock) SetAbsTime(AbsoluteTime) error     { return ErrNoAbsTime }
+func (p *AnalogOutputBlock) GetRelTime() (RelativeTime, error) { return 0, ErrNoRelTime }
+func (p *AnalogOutputBlock) SetRelTime(RelativeTime) error     { return ErrNoRelTime }
+
+// GetValue returns the setpoint.
+func (p *AnalogOutputBlock) GetValue() any { return p.Value }
+
+// SetValue sets the setpoint from a float64, or any integer or float32. It
+// fails if the value doesn't fit the variation's encoding.
+func (p *AnalogOutputBlock) SetValue(value any) error {
+	var setpoint float64
+
+	switch val := value.(type) {
+	case float64:
+		setpoint = val
+	case float32:
+		setpoint = float64(val)
+	case int:
+		setpoint = float64(val)
+	case int16:
+		setpoint = float64(val)
+	case int32:
+		setpoint = float64(val)
+	case int64:
+		setpoint = float64(val)
+	default:
+		return fmt.Errorf("analog output block value must be a number, got %T", value)
+	}
+
+	_, err := encodeNumeric(p.encoding, setpoint)
+	if err != nil {
+		return err
+	}
+
+	p.Value = setpoint
+
+	return nil
+}
+
+// --- Command builder ---
+
+// analogOutputEncodings are the setpoint encodings of Group 41 Var 1-4.
+var analogOutputEncodings = map[uint8]NumericEncoding{
+	1: NumericInt32,
+	2: NumericInt16,
+	3: NumericFloat32,
+	4: NumericFloat64,
+}
+
+// NewAnalogOutputObject returns a Group 41 object of variation commanding
+// each index of setpoints to its value, for Select, Operate, DirOperate and
+// DirOperateNoAck requests. The points are in index order, with 1-octet
+// index prefixes and count (qualifier 0x17), or 2-octet ones (0x28) if an
+// index or the count exceeds 255.
+func NewAnalogOutputObject(variation uint8, setpoints map[int]float64) (*DataObject, error) {
+	encoding, ok := analogOutputEncodings[variation]
+	if !ok {
+		return nil, fmt.Errorf("unsupported analog output block variation %d", variation)
+	}
+
+	indexes := slices.Sorted(maps.Keys(setpoints))
+	if len(indexes) == 0 || len(indexes) > math.MaxUint16 {
+		return nil, fmt.Errorf("analog output object needs 1 to %d setpoints, got %d", math.MaxUint16, len(indexes))
+	}
+
+	//nolint:gosec // G115 - bounded above
+	count := uint32(len(indexes))
+	prefCode, prefSize := Index1Octet, 1
+	rangeField := &CountRangeField{Count: count, byteWidth: 1, code: Count1}
+
+	if indexes[len(indexes)-1] > math.MaxUint8 || count > math.MaxUint8 {
+		prefCode, prefSize = Index2Octet, 2
+		rangeField = &CountRangeField{Count: count, byteWidth: 2, code: Count2}
+	}
+
+	points := make([]Point, 0, len(indexes))
+
+	for _, index := range indexes {
+		point := &AnalogOutputBlock{indexSize: prefSize, encoding: encoding}
+
+		err := point.SetIndex(index)
+		if err == nil {
+			err = point.SetValue(setpoints[index])
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("setpoint for index %d: %w", index, err)
+		}
+
+		points = append(points, point)
+	}
+
+	return &DataObject{
+		Header: ObjectHeader{
+			Group:           41,
+			Variation:       variation,
+			objectType:      objectTypes[groupVariation{41, variation}],
+			PointPrefixCode: prefCode,
+			RangeSpecCode:   rangeField.Code(),
+			RangeField:      rangeField,
+		},
+		Points: points,
+	}, nil
+}
+
+// --- Constructor function ---
+
+// makeAnalogOutputConstructor creates a PointsConstructor for analog output
+// blocks whose setpoint uses encoding.
+func makeAnalogOutputConstructor(encoding NumericEncoding) PointsConstructor {
+	return func(data []byte, num, prefSize int, prefCode PointPrefixCode) ([]Point, int, error) {
+		if slices.Contains([]PointPrefixCode{Size1Octet, Size2Octet, Size4Octet, Reserved}, prefCode) {
+			return nil, 0, fmt.Errorf("analog output blocks can't use point prefix code %s", prefCode)
+		}
+
+		width := prefSize + encoding.Size() + 1
+		size := num * width
+
+		if size > len(data) {
+			return nil, 0, fmt.Errorf("not enough bytes for %d analog output blocks with %d-byte prefix", num, prefSize)
+		}
+
+		pointsOut := make([]Point, 0, num)
+
+		for pointIndex := range num {
+			point := &AnalogOutputBlock{encoding: encoding}
+
+			pointData := data[pointIndex*width : (pointIndex+1)*width]
+
+			err := point.DecodeFromBytes(pointData, prefSize)
+			if err != nil {
+				return pointsOut, size, fmt.Errorf("could not decode analog output block: 0x % X, err: %w", pointData, err)
+			}
+
+			pointsOut = append(pointsOut, point)
+		}
+
+		return pointsOut, size, nil
+	}
+}
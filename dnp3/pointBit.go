@@ -237,7 +237,8 @@ func packerPointsBit(points []Point) ([]byte, error) {
 }
 
 func newPointsBitFlags(data []byte, num, prefSize int, _ PointPrefixCode) ([]Point, int, error) {
-	if num > len(data) {
+	width := 1 + prefSize
+	if num*width > len(data) {
 		return nil, 0, fmt.Errorf("not enough bytes for %d 1-bit points with flags", num)
 	}
 
@@ -245,15 +246,16 @@ func newPointsBitFlags(data []byte, num, prefSize int, _ PointPrefixCode) ([]Poi
 
 	for pointIndex := range num {
 		point := &PointBit{hasFlags: true}
+		pointData := data[pointIndex*width : (pointIndex+1)*width]
 
-		err := point.DecodeFromBytes([]byte{data[pointIndex]}, prefSize)
+		err := point.DecodeFromBytes(pointData, prefSize)
 		if err != nil {
-			return pointsOut, num, fmt.Errorf("could not decode point: 0x % X, err: %w",
-				data[pointIndex], err)
+			return pointsOut, num * width, fmt.Errorf("could not decode point: 0x % X, err: %w",
+				pointData, err)
 		}
 
 		pointsOut = append(pointsOut, point)
 	}
 
-	return pointsOut, num, nil
+	return pointsOut, num * width, nil
 }
@@ -52,7 +52,8 @@ func (l *pointBytesLayout) hasField(field pointField) bool {
 // PointBytes is a general-purpose Point implementation. Optional fields
 // (Flags, AbsoluteTime, RelativeTime) are nil when absent. The presence
 // of each field is determined by the layout, which is set at construction
-// time per DNP3 group/variation.
+// time per DNP3 group/variation. ResolvedTime is only set by
+// ApplicationData.ResolveCTO and is not part of the encoding.
 type PointBytes struct {
 	Index             *int `json:"index,omitempty"`
 	indexSize         int
@@ -62,8 +63,11 @@ type PointBytes struct {
 	Value             []byte        `json:"value,omitempty"`
 	AbsoluteTime      *AbsoluteTime `json:"absolute_time,omitempty"`
 	RelativeTime      *RelativeTime `json:"relative_time,omitempty"`
+	ResolvedTime      *ResolvedTime `json:"resolved_time,omitempty"`
 	layout            pointBytesLayout
 	expectedValueSize int
+	numeric           numericFormat
+	octetString       bool
 }
 
 func (p *PointBytes) DataType() PointDataType { return PointDataTypeBytes }
@@ -133,7 +137,16 @@ func (p *PointBytes) String() string {
 	}
 
 	if len(p.Value) > 0 {
-		parts = append(parts, fmt.Sprintf("Value: 0x % X", p.Value))
+		value := fmt.Sprintf("Value: 0x % X", p.Value)
+		if number := p.numericString(); number != "" {
+			value += fmt.Sprintf(" (%s)", number)
+		}
+
+		if p.octetString {
+			value += fmt.Sprintf(" (%q)", p.Value)
+		}
+
+		parts = append(parts, value)
 	}
 
 	if p.AbsoluteTime != nil {
@@ -144,6 +157,10 @@ func (p *PointBytes) String() string {
 		parts = append(parts, fmt.Sprintf("Timestamp offset: %s", *p.RelativeTime))
 	}
 
+	if p.ResolvedTime != nil {
+		parts = append(parts, fmt.Sprintf("Resolved timestamp: %s", p.ResolvedTime))
+	}
+
 	return strings.Join(parts, "\n")
 }
 
@@ -439,8 +456,8 @@ var (
 	layoutFlags = pointBytesLayout{
 		fields: []pointField{pointFieldFlags, pointFieldValue},
 	}
-	layoutFlagsAbsTime = pointBytesLayout{
-		fields: []pointField{pointFieldFlags, pointFieldAbsTime, pointFieldValue},
+	layoutFlagsValueAbsTime = pointBytesLayout{
+		fields: []pointField{pointFieldFlags, pointFieldValue, pointFieldAbsTime},
 	}
 	layoutValueAbsTime = pointBytesLayout{
 		fields: []pointField{pointFieldValue, pointFieldAbsTime},
@@ -458,7 +475,11 @@ var (
 
 // --- Constructor helpers ---
 
-func newPointBytesWithLayout(layout pointBytesLayout, width int) func() *PointBytes {
+func newPointBytesWithLayout(
+	layout pointBytesLayout,
+	width int,
+	numeric numericFormat,
+) func() *PointBytes {
 	fixedFieldsWidth := 0
 	if layout.hasField(pointFieldFlags) {
 		fixedFieldsWidth += pointFieldWidths[pointFieldFlags]
@@ -481,6 +502,7 @@ func newPointBytesWithLayout(layout pointBytesLayout, width int) func() *PointBy
 		return &PointBytes{
 			layout:            layout,
 			expectedValueSize: calculatedExpectedValueSize,
+			numeric:           numeric,
 		}
 	}
 }
@@ -488,8 +510,33 @@ func newPointBytesWithLayout(layout pointBytesLayout, width int) func() *PointBy
 // makeBytesConstructor creates a PointsConstructor for PointBytes with
 // the given layout and total data width (excluding prefix).
 func makeBytesConstructor(layout pointBytesLayout, width int) PointsConstructor {
-	newPoint := newPointBytesWithLayout(layout, width)
+	return makeBytesConstructorFrom(newPointBytesWithLayout(layout, width, numericFormat{}), width)
+}
+
+// makeNumericConstructor creates a PointsConstructor for PointBytes whose
+// Value holds a number in the given encoding.
+func makeNumericConstructor(
+	layout pointBytesLayout,
+	width int,
+	encoding NumericEncoding,
+) PointsConstructor {
+	return makeNumericConstructorAt(layout, width, encoding, 0)
+}
+
+// makeNumericConstructorAt is makeNumericConstructor for values whose number
+// starts offset bytes into Value (e.g. after a leading status octet).
+func makeNumericConstructorAt(
+	layout pointBytesLayout,
+	width int,
+	encoding NumericEncoding,
+	offset int,
+) PointsConstructor {
+	numeric := numericFormat{encoding: encoding, offset: offset}
+
+	return makeBytesConstructorFrom(newPointBytesWithLayout(layout, width, numeric), width)
+}
 
+func makeBytesConstructorFrom(newPoint func() *PointBytes, width int) PointsConstructor {
 	return func(data []byte, num, prefSize int, prefCode PointPrefixCode) ([]Point, int, error) {
 		return newPointsBytesGeneric(newPoint, data, width, num, prefSize, prefCode)
 	}
@@ -0,0 +1,266 @@
+package dnp3
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PointDataTypeCROB identifies a CROB point.
+const PointDataTypeCROB PointDataType = "crob"
+
+// crobSize is the wire size of a CROB or PCB, excluding any prefix.
+const crobSize = 11
+
+// OpType is the operation type in the low nibble of a CROB control code.
+//
+//go:generate stringer -type=OpType -trimprefix=OpType
+type OpType uint8 // only 4 bits
+
+const (
+	OpTypeNul OpType = iota
+	OpTypePulseOn
+	OpTypePulseOff
+	OpTypeLatchOn
+	OpTypeLatchOff
+)
+
+// TripCloseCode selects which relay of a trip/close pair a CROB operates
+// (top 2 bits of the control code).
+//
+//go:generate stringer -type=TripCloseCode -trimprefix=TCC
+type TripCloseCode uint8 // only 2 bits
+
+const (
+	TCCNul TripCloseCode = iota
+	TCCClose
+	TCCTrip
+	TCCReserved
+)
+
+// CommandStatus is the status code an outstation echoes back for a control
+// (CROB, analog output block) request.
+//
+//go:generate stringer -type=CommandStatus -trimprefix=CommandStatus
+type CommandStatus uint8 // only 7 bits
+
+const (
+	CommandStatusSuccess CommandStatus = iota
+	CommandStatusTimeout
+	CommandStatusNoSelect
+	CommandStatusFormatError
+	CommandStatusNotSupported
+	CommandStatusAlreadyActive
+	CommandStatusHardwareError
+	CommandStatusLocal
+	CommandStatusTooManyOps
+	CommandStatusNotAuthorized
+	CommandStatusAutomationInhibit
+	CommandStatusProcessingLimited
+	CommandStatusOutOfRange
+	CommandStatusDownstreamLocal
+	CommandStatusAlreadyComplete
+	CommandStatusBlocked
+	CommandStatusCanceled
+	CommandStatusBlockedOtherMaster
+	CommandStatusDownstreamFail
+	CommandStatusNonParticipating CommandStatus = 126
+	CommandStatusUndefined        CommandStatus = 127
+)
+
+// CROB is a Control Relay Output Block (Group 12 Var 1), also used for the
+// Pattern Control Block (Group 12 Var 2). OnTime and OffTime are in
+// milliseconds.
+type CROB struct {
+	Index         *int `json:"index,omitempty"`
+	indexSize     int
+	OpType        OpType        `json:"op_type"`
+	Queue         bool          `json:"queue"`
+	Clear         bool          `json:"clear"`
+	TripCloseCode TripCloseCode `json:"trip_close_code"`
+	Count         uint8         `json:"count"`
+	OnTime        uint32        `json:"on_time"`
+	OffTime       uint32        `json:"off_time"`
+	Status        CommandStatus `json:"status"`
+}
+
+func (p *CROB) DataType() PointDataType { return PointDataTypeCROB }
+
+func (p *CROB) DecodeFromBytes(data []byte, prefSize int) error {
+	if len(data) != prefSize+crobSize {
+		return fmt.Errorf("CROB requires %d bytes, got %d", prefSize+crobSize, len(data))
+	}
+
+	if prefSize > 0 {
+		index, err := prefixToInt(data[:prefSize])
+		if err != nil {
+			return fmt.Errorf("could not decode index prefix: %w", err)
+		}
+
+		p.Index = &index
+		p.indexSize = prefSize
+	}
+
+	data = data[prefSize:]
+
+	p.OpType = OpType(data[0] & 0b00001111)
+	p.Queue = data[0]&0b00010000 != 0
+	p.Clear = data[0]&0b00100000 != 0
+	p.TripCloseCode = TripCloseCode(data[0] >> 6)
+	p.Count = data[1]
+	p.OnTime = binary.LittleEndian.Uint32(data[2:6])
+	p.OffTime = binary.LittleEndian.Uint32(data[6:10])
+	// The reserved top bit is kept as received; Validate reports it.
+	p.Status = CommandStatus(data[10])
+
+	return nil
+}
+
+func (p *CROB) SerializeTo() ([]byte, error) {
+	var output []byte
+
+	if p.indexSize > 0 {
+		indexBytes, err := intToPrefix(*p.Index, p.indexSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode index: %w", err)
+		}
+
+		output = append(output, indexBytes...)
+	}
+
+	if p.OpType > 0b00001111 {
+		return nil, fmt.Errorf("CROB op type %d exceeds 4 bits", p.OpType)
+	}
+
+	if p.TripCloseCode > 0b00000011 {
+		return nil, fmt.Errorf("CROB trip-close code %d exceeds 2 bits", p.TripCloseCode)
+	}
+
+	if p.Status > 0b01111111 {
+		return nil, fmt.Errorf("CROB status %d exceeds 7 bits", p.Status)
+	}
+
+	controlCode := byte(p.OpType) | byte(p.TripCloseCode)<<6
+	if p.Queue {
+		controlCode |= 0b00010000
+	}
+
+	if p.Clear {
+		controlCode |= 0b00100000
+	}
+
+	output = append(output, controlCode, p.Count)
+	output = binary.LittleEndian.AppendUint32(output, p.OnTime)
+	output = binary.LittleEndian.AppendUint32(output, p.OffTime)
+
+	return append(output, byte(p.Status)), nil
+}
+
+func (p *CROB) String() string {
+	var parts []string
+
+	if p.indexSize > 0 {
+		parts = append(parts, fmt.Sprintf("Index: %d", *p.Index))
+	}
+
+	parts = append(parts,
+		fmt.Sprintf("Op Type : (%d) %s", p.OpType, p.OpType),
+		fmt.Sprintf("Queue   : %t", p.Queue),
+		fmt.Sprintf("Clear   : %t", p.Clear),
+		fmt.Sprintf("TCC     : (%d) %s", p.TripCloseCode, p.TripCloseCode),
+		fmt.Sprintf("Count   : %d", p.Count),
+		fmt.Sprintf("On Time : %d ms", p.OnTime),
+		fmt.Sprintf("Off Time: %d ms", p.OffTime),
+		fmt.Sprintf("Status  : (%d) %s", p.Status, p.Status),
+	)
+
+	return strings.Join(parts, "\n")
+}
+
+func (p *CROB) Fields() PointFields {
+	return PointFields{
+		Index: p.indexSize > 0,
+		Value: true,
+	}
+}
+
+// --- Get/Set methods ---
+
+func (p *CROB) GetIndex() (int, error) {
+	if p.indexSize == 0 {
+		return 0, ErrNoIndex
+	}
+
+	return *p.Index, nil
+}
+
+func (p *CROB) SetIndex(value int) error {
+	return setIndex(&p.Index, &p.indexSize, value)
+}
+
+func (p *CROB) GetFlags() (PointFlags, error)     { return PointFlags{}, ErrNoFlags }
+func (p *CROB) SetFlags(PointFlags) error         { return ErrNoFlags }
+func (p *CROB) GetAbsTime() (AbsoluteTime, error) { return AbsoluteTime{}, ErrNoAbsTime }
+func (p *CROB) SetAbsTime(AbsoluteTime) error     { return ErrNoAbsTime }
+func (p *CROB) GetRelTime() (RelativeTime, error) { return 0, ErrNoRelTime }
+func (p *CROB) SetRelTime(RelativeTime) error     { return ErrNoRelTime }
+
+// GetValue returns a copy of the CROB.
+func (p *CROB) GetValue() any { return *p }
+
+// SetValue replaces the control fields with those of a CROB or *CROB. The
+// index is left unchanged.
+func (p *CROB) SetValue(value any) error {
+	var crob CROB
+
+	switch val := value.(type) {
+	case CROB:
+		crob = val
+	case *CROB:
+		crob = *val
+	default:
+		return fmt.Errorf("CROB value must be CROB, got %T", value)
+	}
+
+	if crob.Status > 0b01111111 {
+		return fmt.Errorf("CROB status %d exceeds 7 bits", crob.Status)
+	}
+
+	crob.Index, crob.indexSize = p.Index, p.indexSize
+	*p = crob
+
+	return nil
+}
+
+// --- Constructor function ---
+
+func newPointsCROB(data []byte, num, prefSize int, prefCode PointPrefixCode) ([]Point, int, error) {
+	if slices.Contains([]PointPrefixCode{Size1Octet, Size2Octet, Size4Octet, Reserved}, prefCode) {
+		return nil, 0, fmt.Errorf("CROBs can't use point prefix code %s", prefCode)
+	}
+
+	width := prefSize + crobSize
+	size := num * width
+
+	if size > len(data) {
+		return nil, 0, fmt.Errorf("not enough bytes for %d CROBs with %d-byte prefix", num, prefSize)
+	}
+
+	pointsOut := make([]Point, 0, num)
+
+	for pointIndex := range num {
+		point := &CROB{}
+
+		pointData := data[pointIndex*width : (pointIndex+1)*width]
+
+		err := point.DecodeFromBytes(pointData, prefSize)
+		if err != nil {
+			return pointsOut, size, fmt.Errorf("could not decode CROB: 0x % X, err: %w", pointData, err)
+		}
+
+		pointsOut = append(pointsOut, point)
+	}
+
+	return pointsOut, size, nil
+}
@@ -0,0 +1,223 @@
+package dnp3
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// NumericEncoding describes how the Value of a counter, analog or analog
+// output point encodes its number on the wire. Every encoding is little-endian.
+//
+//go:generate stringer -type=NumericEncoding
+type NumericEncoding uint8
+
+const (
+	NumericNone NumericEncoding = iota // not a numeric point
+	NumericUint16
+	NumericUint32
+	NumericInt16
+	NumericInt32
+	NumericFloat32
+	NumericFloat64
+)
+
+// Size returns the number of bytes used by the encoding.
+func (enc NumericEncoding) Size() int {
+	switch enc {
+	case NumericUint16, NumericInt16:
+		return 2
+	case NumericUint32, NumericInt32, NumericFloat32:
+		return 4
+	case NumericFloat64:
+		return 8
+	case NumericNone:
+		return 0
+	default:
+		return 0
+	}
+}
+
+// IsFloat reports whether the encoding is an IEEE-754 floating point number.
+func (enc NumericEncoding) IsFloat() bool {
+	return enc == NumericFloat32 || enc == NumericFloat64
+}
+
+// ErrNoNumeric is returned by the numeric accessors of points whose
+// group/variation does not carry a number.
+var ErrNoNumeric = errors.New("point type does not have a numeric value")
+
+// numericFormat locates the number inside a PointBytes Value.
+type numericFormat struct {
+	encoding NumericEncoding
+	offset   int
+}
+
+// NumericEncoding returns the encoding of the point's Value, or NumericNone
+// if its group/variation does not carry a number.
+func (p *PointBytes) NumericEncoding() NumericEncoding {
+	return p.numeric.encoding
+}
+
+// AsInt64 decodes an integer Value. Floating point encodings return an error;
+// use AsFloat64 for those.
+func (p *PointBytes) AsInt64() (int64, error) {
+	raw, err := p.numericBytes()
+	if err != nil {
+		return 0, err
+	}
+
+	switch p.numeric.encoding {
+	case NumericUint16:
+		return int64(binary.LittleEndian.Uint16(raw)), nil
+	case NumericUint32:
+		return int64(binary.LittleEndian.Uint32(raw)), nil
+	case NumericInt16:
+		return int64(int16(binary.LittleEndian.Uint16(raw))), nil //nolint:gosec // two's complement reinterpretation
+	case NumericInt32:
+		return int64(int32(binary.LittleEndian.Uint32(raw))), nil //nolint:gosec // two's complement reinterpretation
+	case NumericFloat32, NumericFloat64:
+		return 0, fmt.Errorf("value is encoded as %s, use AsFloat64", p.numeric.encoding)
+	case NumericNone:
+		return 0, ErrNoNumeric
+	default:
+		return 0, fmt.Errorf("unexpected numeric encoding %d", p.numeric.encoding)
+	}
+}
+
+// AsFloat64 decodes the Value of any numeric encoding as a float64.
+func (p *PointBytes) AsFloat64() (float64, error) {
+	switch p.numeric.encoding {
+	case NumericFloat32:
+		raw, err := p.numericBytes()
+		if err != nil {
+			return 0, err
+		}
+
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(raw))), nil
+	case NumericFloat64:
+		raw, err := p.numericBytes()
+		if err != nil {
+			return 0, err
+		}
+
+		return math.Float64frombits(binary.LittleEndian.Uint64(raw)), nil
+	case NumericNone, NumericUint16, NumericUint32, NumericInt16, NumericInt32:
+		integer, err := p.AsInt64()
+		if err != nil {
+			return 0, err
+		}
+
+		return float64(integer), nil
+	default:
+		return 0, fmt.Errorf("unexpected numeric encoding %d", p.numeric.encoding)
+	}
+}
+
+// SetNumeric encodes value into the point's Value using its numeric encoding.
+// Integer encodings reject values that are not whole numbers or do not fit;
+// Float32 rejects finite values beyond its range. Bytes of Value outside the
+// number (such as a trailing status octet) are left unchanged.
+func (p *PointBytes) SetNumeric(value float64) error {
+	if p.numeric.encoding == NumericNone {
+		return ErrNoNumeric
+	}
+
+	encoded, err := encodeNumeric(p.numeric.encoding, value)
+	if err != nil {
+		return err
+	}
+
+	updated := paddedBytes(append([]byte(nil), p.Value...), p.expectedValueSize)
+	if len(updated) < p.numeric.offset+len(encoded) {
+		updated = paddedBytes(updated, p.numeric.offset+len(encoded))
+	}
+
+	copy(updated[p.numeric.offset:], encoded)
+	p.Value = updated
+
+	return nil
+}
+
+// numericBytes returns the slice of Value holding the number.
+func (p *PointBytes) numericBytes() ([]byte, error) {
+	if p.numeric.encoding == NumericNone {
+		return nil, ErrNoNumeric
+	}
+
+	value := p.encodeFieldValue()
+	end := p.numeric.offset + p.numeric.encoding.Size()
+
+	if len(value) < end {
+		return nil, fmt.Errorf("value has %d bytes, %s needs %d", len(value), p.numeric.encoding, end)
+	}
+
+	return value[p.numeric.offset:end], nil
+}
+
+// numericString formats the decoded number for String output.
+func (p *PointBytes) numericString() string {
+	if p.numeric.encoding.IsFloat() {
+		value, err := p.AsFloat64()
+		if err != nil {
+			return ""
+		}
+
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+
+	value, err := p.AsInt64()
+	if err != nil {
+		return ""
+	}
+
+	return strconv.FormatInt(value, 10)
+}
+
+// encodeNumeric range-checks value and encodes it little-endian.
+func encodeNumeric(encoding NumericEncoding, value float64) ([]byte, error) {
+	if !encoding.IsFloat() {
+		if math.IsNaN(value) || math.IsInf(value, 0) || value != math.Trunc(value) {
+			return nil, fmt.Errorf("%s can't hold non-integer value %v", encoding, value)
+		}
+	}
+
+	var lower, upper float64
+
+	switch encoding {
+	case NumericUint16:
+		lower, upper = 0, math.MaxUint16
+	case NumericUint32:
+		lower, upper = 0, math.MaxUint32
+	case NumericInt16:
+		lower, upper = math.MinInt16, math.MaxInt16
+	case NumericInt32:
+		lower, upper = math.MinInt32, math.MaxInt32
+	case NumericFloat32:
+		if !math.IsInf(value, 0) && math.Abs(value) > math.MaxFloat32 {
+			return nil, fmt.Errorf("value %v exceeds %s range", value, encoding)
+		}
+
+		return binary.LittleEndian.AppendUint32(nil, math.Float32bits(float32(value))), nil
+	case NumericFloat64:
+		return binary.LittleEndian.AppendUint64(nil, math.Float64bits(value)), nil
+	case NumericNone:
+		return nil, ErrNoNumeric
+	default:
+		return nil, fmt.Errorf("unexpected numeric encoding %d", encoding)
+	}
+
+	if value < lower || value > upper {
+		return nil, fmt.Errorf("value %v outside %s range [%v, %v]", value, encoding, lower, upper)
+	}
+
+	// #nosec G115 -- range checked above
+	switch encoding.Size() {
+	case 2:
+		return binary.LittleEndian.AppendUint16(nil, uint16(int64(value))), nil
+	default:
+		return binary.LittleEndian.AppendUint32(nil, uint32(int64(value))), nil
+	}
+}
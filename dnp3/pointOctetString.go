@@ -0,0 +1,85 @@
+package dnp3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrNotOctetString is returned when a point that isn't an octet string or
+// virtual terminal object is read or set as a string.
+var ErrNotOctetString = errors.New("point is not an octet string")
+
+// octetStringGroups are the groups whose variation is the length of the
+// value: octet strings (110, 111) and virtual terminal data (112, 113).
+var octetStringGroups = map[uint8]string{
+	110: "(Static) Octet String",
+	111: "(Event) Octet String Event",
+	112: "(Command) Virtual Terminal Output Block",
+	113: "(Event) Virtual Terminal Event Data",
+}
+
+// octetStringType returns the objectTypes entry of an octet string or
+// virtual terminal variation, whose Value is variation bytes long.
+func octetStringType(group, variation uint8) *objectType {
+	return &objectType{
+		Description: fmt.Sprintf("%s - Length %d", octetStringGroups[group], variation),
+		Constructor: makeOctetStringConstructor(int(variation)),
+		Packer:      packPointsBytes,
+	}
+}
+
+// makeOctetStringConstructor creates a PointsConstructor for octet string
+// points with a width-byte Value.
+func makeOctetStringConstructor(width int) PointsConstructor {
+	newPoint := newPointBytesWithLayout(layoutValue, width, numericFormat{})
+
+	return makeBytesConstructorFrom(func() *PointBytes {
+		point := newPoint()
+		point.octetString = true
+
+		return point
+	}, width)
+}
+
+// IsOctetString reports whether the point is an octet string or virtual
+// terminal object (Groups 110-113), whose Value is text.
+func (p *PointBytes) IsOctetString() bool {
+	return p.octetString
+}
+
+// AsString returns the Value of an octet string or virtual terminal point as
+// a string.
+func (p *PointBytes) AsString() (string, error) {
+	if !p.octetString {
+		return "", ErrNotOctetString
+	}
+
+	return string(p.Value), nil
+}
+
+// SetString sets the Value of an octet string or virtual terminal point. The
+// string must be exactly as long as the variation.
+func (p *PointBytes) SetString(value string) error {
+	if !p.octetString {
+		return ErrNotOctetString
+	}
+
+	return p.SetValue([]byte(value))
+}
+
+// MarshalJSON adds the Value of an octet string point as "text", next to the
+// encoded bytes. The text is ignored when unmarshaling.
+func (p *PointBytes) MarshalJSON() ([]byte, error) {
+	type plain PointBytes
+
+	if !p.octetString {
+		return json.Marshal((*plain)(p)) //nolint:wrapcheck // plain struct encoding
+	}
+
+	return json.Marshal(struct { //nolint:wrapcheck // plain struct encoding
+		*plain
+
+		Text string `json:"text"`
+	}{(*plain)(p), string(p.Value)})
+}
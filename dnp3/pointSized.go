@@ -0,0 +1,176 @@
+package dnp3
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// sizedObject holds what the objects that are preceded by their size (those
+// sent with qualifier 0x5B) share: the width of that size prefix, and the
+// Point fields none of them have.
+type sizedObject struct {
+	sizeSize int
+}
+
+func (s *sizedObject) setSizeSize(sizeSize int) {
+	s.sizeSize = sizeSize
+}
+
+// body checks the object size prefix of data and returns the object it
+// covers.
+func (s *sizedObject) body(data []byte, prefSize int) ([]byte, error) {
+	if prefSize == 0 {
+		return nil, errors.New("object requires an object size prefix")
+	}
+
+	if len(data) < prefSize {
+		return nil, fmt.Errorf("object requires a %d-byte size prefix, got %d bytes", prefSize, len(data))
+	}
+
+	size, err := prefixToInt(data[:prefSize])
+	if err != nil {
+		return nil, fmt.Errorf("could not decode size prefix: %w", err)
+	}
+
+	if len(data) < prefSize+size {
+		return nil, fmt.Errorf("object of %d bytes, got %d", size, len(data)-prefSize)
+	}
+
+	s.sizeSize = prefSize
+
+	return data[prefSize : prefSize+size], nil
+}
+
+// prefixed returns body preceded by its size prefix. Objects built by hand,
+// rather than by NewPoints, get the usual 2-octet prefix (qualifier 0x5B).
+func (s *sizedObject) prefixed(body []byte) ([]byte, error) {
+	width := s.sizeSize
+	if width == 0 {
+		width = 2
+	}
+
+	prefix, err := intToPrefixSized(len(body), width)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode size prefix: %w", err)
+	}
+
+	return append(prefix, body...), nil
+}
+
+func (s *sizedObject) Fields() PointFields {
+	return PointFields{
+		Size:  true,
+		Value: true,
+	}
+}
+
+func (s *sizedObject) GetIndex() (int, error)            { return 0, ErrNoIndex }
+func (s *sizedObject) SetIndex(int) error                { return ErrNoIndex }
+func (s *sizedObject) GetFlags() (PointFlags, error)     { return PointFlags{}, ErrNoFlags }
+func (s *sizedObject) SetFlags(PointFlags) error         { return ErrNoFlags }
+func (s *sizedObject) GetAbsTime() (AbsoluteTime, error) { return AbsoluteTime{}, ErrNoAbsTime }
+func (s *sizedObject) SetAbsTime(AbsoluteTime) error     { return ErrNoAbsTime }
+func (s *sizedObject) GetRelTime() (RelativeTime, error) { return 0, ErrNoRelTime }
+func (s *sizedObject) SetRelTime(RelativeTime) error     { return ErrNoRelTime }
+
+// NewSizedObject returns an object of group and variation holding point,
+// preceded by its 2-octet size and a 1-octet count (qualifier 0x5B), as the
+// file transfer objects are sent.
+func NewSizedObject(group, variation uint8, point Point) DataObject {
+	rangeField := NewVariableCountRangeField(1)
+
+	return DataObject{
+		Header: ObjectHeader{
+			Group:           group,
+			Variation:       variation,
+			PointPrefixCode: Size2Octet,
+			RangeSpecCode:   rangeField.Code(),
+			RangeField:      rangeField,
+		},
+		Points: []Point{point},
+	}
+}
+
+// --- Constructor function ---
+
+// makeSizedConstructor returns the constructor of a size-prefixed object
+// type. Each object is preceded by its size, so the points are decoded one
+// at a time.
+func makeSizedConstructor[T any, P interface {
+	*T
+	Point
+	setSizeSize(sizeSize int)
+}]() PointsConstructor {
+	return func(data []byte, num, prefSize int, prefCode PointPrefixCode) ([]Point, int, error) {
+		err := checkSizePrefix(prefCode)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		pointsOut := make([]Point, 0, num)
+		offset := 0
+
+		for range num {
+			if len(data) < offset+prefSize {
+				return pointsOut, offset, fmt.Errorf("not enough bytes for the size prefix of object %d", len(pointsOut))
+			}
+
+			size, err := prefixToInt(data[offset : offset+prefSize])
+			if err != nil {
+				return pointsOut, offset, fmt.Errorf("could not decode size prefix: %w", err)
+			}
+
+			if len(data) < offset+prefSize+size {
+				return pointsOut, offset, fmt.Errorf("not enough bytes for a %d-byte object", size)
+			}
+
+			point := P(new(T))
+
+			err = point.DecodeFromBytes(data[offset:offset+prefSize+size], prefSize)
+			if err != nil {
+				return pointsOut, offset, fmt.Errorf("could not decode object: %w", err)
+			}
+
+			pointsOut = append(pointsOut, point)
+			offset += prefSize + size
+		}
+
+		return pointsOut, offset, nil
+	}
+}
+
+// makeSizedBlank returns the blank points constructor of a size-prefixed
+// object type: an empty object doesn't decode, so its zero value is built
+// directly.
+func makeSizedBlank[T any, P interface {
+	*T
+	Point
+	setSizeSize(sizeSize int)
+}]() PointsBlanker {
+	return func(num int, prefCode PointPrefixCode) ([]Point, error) {
+		err := checkSizePrefix(prefCode)
+		if err != nil {
+			return nil, err
+		}
+
+		pointsOut := make([]Point, 0, num)
+
+		for range num {
+			point := P(new(T))
+			point.setSizeSize(prefCode.GetPointPrefixSize())
+			pointsOut = append(pointsOut, point)
+		}
+
+		return pointsOut, nil
+	}
+}
+
+// checkSizePrefix rejects a point prefix code that isn't an object size.
+func checkSizePrefix(prefCode PointPrefixCode) error {
+	if !slices.Contains([]PointPrefixCode{Size1Octet, Size2Octet, Size4Octet}, prefCode) {
+		return fmt.Errorf("object requires an object size prefix, got point prefix code %s", prefCode)
+	}
+
+	return nil
+}
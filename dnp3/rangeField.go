@@ -81,6 +81,52 @@ func rangeFieldConstructorFor(code RangeSpecCode) (rangeFieldConstructor, error)
 	return constructor, nil
 }
 
+// NewRangeField returns an empty RangeField of the concrete type selected by
+// code, ready to be populated via DecodeFromBytes or by setting fields.
+//
+//nolint:ireturn // the concrete type depends on code.
+func NewRangeField(code RangeSpecCode) (RangeField, error) {
+	ctor, err := rangeFieldConstructorFor(code)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctor(), nil
+}
+
+// NewStartStopRangeField returns a start/stop range using the narrowest index
+// width (StartStop1, StartStop2 or StartStop4) that holds stop.
+func NewStartStopRangeField(start, stop uint32) *StartStopRangeField {
+	switch {
+	case stop <= 0xFF:
+		return &StartStopRangeField{Start: start, Stop: stop, byteWidth: 1, code: StartStop1}
+	case stop <= 0xFFFF:
+		return &StartStopRangeField{Start: start, Stop: stop, byteWidth: 2, code: StartStop2}
+	default:
+		return &StartStopRangeField{Start: start, Stop: stop, byteWidth: 4, code: StartStop4}
+	}
+}
+
+// NewCountRangeField returns a count range using the narrowest width (Count1,
+// Count2 or Count4) that holds count.
+func NewCountRangeField(count uint32) *CountRangeField {
+	switch {
+	case count <= 0xFF:
+		return &CountRangeField{Count: count, byteWidth: 1, code: Count1}
+	case count <= 0xFFFF:
+		return &CountRangeField{Count: count, byteWidth: 2, code: Count2}
+	default:
+		return &CountRangeField{Count: count, byteWidth: 4, code: Count4}
+	}
+}
+
+// NewVariableCountRangeField returns a 1-octet count range with variable
+// format (Count1Variable), as used with object size prefixes by the file
+// transfer objects (Group 70).
+func NewVariableCountRangeField(count uint8) *CountRangeField {
+	return &CountRangeField{Count: uint32(count), byteWidth: 1, variable: true, code: Count1Variable}
+}
+
 // StartStopRangeField represents range spec codes 0-5: start/stop index pairs
 // with configurable byte width (1, 2, or 4) and optional virtual addressing.
 type StartStopRangeField struct {
@@ -32,11 +32,28 @@ func NewApplicationRequestFromBytes(data []byte) (*ApplicationRequest, error) {
 }
 
 func (appreq *ApplicationRequest) DecodeFromBytes(data []byte) error {
+	return appreq.decodeWithOptions(data, strictDecoding)
+}
+
+// decodeWithOptions is DecodeFromBytes with opts. Without
+// opts.Strict, the objects are decoded as far as possible (see
+// ApplicationData.decodeWithOptions).
+func (appreq *ApplicationRequest) decodeWithOptions(data []byte, opts DecodeOptions) error {
+	if len(data) < 2 {
+		return fmt.Errorf("application request requires at least 2 bytes, got %d", len(data))
+	}
+
 	appreq.Control.FromByte(data[0])
 
 	appreq.FunctionCode = RequestFunctionCode(data[1])
 
-	err := appreq.Data.DecodeFromBytes(data[2:])
+	var err error
+	if appreq.FunctionCode.headersOnly() {
+		err = appreq.Data.decodeHeadersFromBytes(data[2:], opts)
+	} else {
+		err = appreq.Data.decodeWithOptions(data[2:], opts)
+	}
+
 	if err != nil {
 		return fmt.Errorf("couldn't create AppReq Data DecodeFromBytes: %w", err)
 	}
@@ -90,7 +107,7 @@ func (appreq *ApplicationRequest) GetSequence() uint8 {
 }
 
 func (appreq *ApplicationRequest) SetSequence(s uint8) error {
-	if s >= 0b00001111 {
+	if s > 0b00001111 {
 		return fmt.Errorf("application sequence is only 4 bits, got %d", s)
 	}
 
@@ -157,3 +174,15 @@ const (
 	AuthenticationRequest
 	AuthenticationRequestNoAck // 0x21
 )
+
+// headersOnly reports whether the objects of a request with this function
+// code are bare headers (plus any index prefixes) rather than point values.
+func (fc RequestFunctionCode) headersOnly() bool {
+	switch fc { //nolint:exhaustive // only codes whose objects carry no values
+	case Read, Freeze, FreezeNoAck, FreezeClear, FreezeClearNoAck,
+		EnableUnsolicited, DisableUnsolicited, AssignClass:
+		return true
+	default:
+		return false
+	}
+}
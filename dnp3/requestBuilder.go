@@ -0,0 +1,155 @@
+package dnp3
+
+import (
+	"errors"
+	"fmt"
+)
+
+// RequestBuilder builds an ApplicationRequest, and the frame that carries
+// it, one object header at a time:
+//
+//	frame, err := dnp3.NewRequest(dnp3.Read).Class(1, 2, 3).Range(30, 1, 0, 9).Frame(1, 10)
+//
+// Each header gets the narrowest qualifier for its range. Errors from any
+// step are returned by Request or Frame.
+type RequestBuilder struct {
+	request ApplicationRequest
+	err     error
+}
+
+// NewRequest starts a request with function code fc and sequence number 0.
+func NewRequest(fc RequestFunctionCode) *RequestBuilder {
+	return &RequestBuilder{request: ApplicationRequest{
+		Control:      ApplicationControl{First: true, Final: true},
+		FunctionCode: fc,
+	}}
+}
+
+// Sequence sets the application sequence number (0-15).
+func (b *RequestBuilder) Sequence(sequence uint8) *RequestBuilder {
+	err := b.request.SetSequence(sequence)
+	if err != nil {
+		b.fail(err)
+	}
+
+	return b
+}
+
+// Class adds a Group 60 "all objects" header (qualifier 0x06) for each class:
+// 0 for static data, 1-3 for events.
+func (b *RequestBuilder) Class(classes ...uint8) *RequestBuilder {
+	for _, class := range classes {
+		if class > 3 {
+			b.fail(fmt.Errorf("class must be 0-3, got %d", class))
+
+			continue
+		}
+
+		b.All(60, class+1)
+	}
+
+	return b
+}
+
+// All adds a header for every point of group/variation (qualifier 0x06).
+func (b *RequestBuilder) All(group, variation uint8) *RequestBuilder {
+	return b.header(group, variation, &AllRangeField{})
+}
+
+// Range adds a header for points start to stop of group/variation, with
+// 1-, 2- or 4-octet start and stop indexes (qualifier 0x00, 0x01 or 0x02),
+// whichever is the narrowest that holds stop.
+func (b *RequestBuilder) Range(group, variation uint8, start, stop uint32) *RequestBuilder {
+	if start > stop {
+		b.fail(fmt.Errorf("g%dv%d: range start %d exceeds stop %d", group, variation, start, stop))
+
+		return b
+	}
+
+	return b.header(group, variation, NewStartStopRangeField(start, stop))
+}
+
+// Count adds a header for the first count points (or events) of
+// group/variation, with a 1-, 2- or 4-octet count (qualifier 0x07, 0x08 or
+// 0x09).
+func (b *RequestBuilder) Count(group, variation uint8, count uint32) *RequestBuilder {
+	return b.header(group, variation, NewCountRangeField(count))
+}
+
+// Object adds a complete object, such as one from NewAnalogOutputObject, as
+// is.
+func (b *RequestBuilder) Object(object DataObject) *RequestBuilder {
+	b.request.Data.Objects = append(b.request.Data.Objects, object)
+
+	return b
+}
+
+// Request returns the request built so far.
+func (b *RequestBuilder) Request() (*ApplicationRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	request := b.request
+	request.Data.Objects = append([]DataObject(nil), b.request.Data.Objects...)
+
+	return &request, nil
+}
+
+// Frame returns the request in a frame from the master at source to the
+// outstation at destination, sent as unconfirmed user data with FIR and FIN
+// set and transport sequence 0. Requests too large for one frame fail; use
+// SegmentApplication for those.
+func (b *RequestBuilder) Frame(source, destination uint16) (*Frame, error) {
+	request, err := b.Request()
+	if err != nil {
+		return nil, err
+	}
+
+	var dataLink DataLink
+	dataLink.Source = source
+	dataLink.Destination = destination
+	dataLink.Control.Direction = true
+	dataLink.Control.Primary = true
+	dataLink.Control.FunctionCode = UnconfirmedUserData
+
+	frames, err := SegmentApplication(dataLink, request, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(frames) != 1 {
+		return nil, fmt.Errorf("request needs %d frames, use SegmentApplication", len(frames))
+	}
+
+	return frames[0], nil
+}
+
+// rangeSpecField is a RangeField that knows its range specifier code.
+type rangeSpecField interface {
+	RangeField
+	Code() RangeSpecCode
+}
+
+func (b *RequestBuilder) header(group, variation uint8, rangeField rangeSpecField) *RequestBuilder {
+	definition, ok := objectTypes[groupVariation{group, variation}]
+	if !ok {
+		b.fail(fmt.Errorf("unsupported group/variation: %d/%d", group, variation))
+
+		return b
+	}
+
+	b.request.Data.Objects = append(b.request.Data.Objects, DataObject{Header: ObjectHeader{
+		Group:         group,
+		Variation:     variation,
+		objectType:    definition,
+		RangeSpecCode: rangeField.Code(),
+		RangeField:    rangeField,
+	}})
+
+	return b
+}
+
+func (b *RequestBuilder) fail(err error) {
+	b.err = errors.Join(b.err, err)
+}
@@ -34,16 +34,27 @@ func NewApplicationResponseFromBytes(data []byte) (*ApplicationResponse, error)
 }
 
 func (appresp *ApplicationResponse) DecodeFromBytes(data []byte) error {
+	return appresp.decodeWithOptions(data, strictDecoding)
+}
+
+// decodeWithOptions is DecodeFromBytes with opts. Without
+// opts.Strict, IIN 2.6 and 2.7 are accepted and the objects are decoded as
+// far as possible (see ApplicationData.decodeWithOptions).
+func (appresp *ApplicationResponse) decodeWithOptions(data []byte, opts DecodeOptions) error {
+	if len(data) < 4 {
+		return fmt.Errorf("application response requires at least 4 bytes, got %d", len(data))
+	}
+
 	appresp.Control.FromByte(data[0])
 
 	appresp.FunctionCode = ResponseFunctionCode(data[1])
 
-	err := appresp.InternalIndications.DecodeFromBytes(data[2:4])
+	err := appresp.InternalIndications.decodeWithOptions(data[2:4], opts)
 	if err != nil {
 		return fmt.Errorf("can't create application response: %w", err)
 	}
 
-	err = appresp.Data.DecodeFromBytes(data[4:])
+	err = appresp.Data.decodeWithOptions(data[4:], opts)
 	if err != nil {
 		return fmt.Errorf("couldn't create AppReq Data DecodeFromBytes: %w", err)
 	}
@@ -99,7 +110,7 @@ func (appresp *ApplicationResponse) GetSequence() uint8 {
 }
 
 func (appresp *ApplicationResponse) SetSequence(s uint8) error {
-	if s >= 0b00001111 {
+	if s > 0b00001111 {
 		return fmt.Errorf("application sequence is only 4 bits, got %d", s)
 	}
 
@@ -179,6 +190,12 @@ func NewApplicationInternalIndicationsFromBytes(
 }
 
 func (appiin *ApplicationInternalIndications) DecodeFromBytes(data []byte) error {
+	return appiin.decodeWithOptions(data, strictDecoding)
+}
+
+// decodeWithOptions is DecodeFromBytes with opts. Without
+// opts.Strict, IIN 2.6 and 2.7 are accepted in Reserved1 and Reserved2.
+func (appiin *ApplicationInternalIndications) decodeWithOptions(data []byte, opts DecodeOptions) error {
 	if len(data) != 2 {
 		return fmt.Errorf(
 			"ApplicationInternalIndications requires exactly 2 bytes, got %d",
@@ -206,7 +223,7 @@ func (appiin *ApplicationInternalIndications) DecodeFromBytes(data []byte) error
 
 	appiin.Reserved2 = (msb & 0b10000000) != 0
 	if (msb & 0b11000000) != 0 {
-		return errors.New("IIN 2.6 and 2.7 must be set to 0")
+		return opts.tolerate("application", data, errors.New("IIN 2.6 and 2.7 must be set to 0"))
 	}
 
 	return nil
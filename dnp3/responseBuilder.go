@@ -0,0 +1,457 @@
+package dnp3
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+)
+
+// DefaultMaxFragmentSize is the largest application fragment every DNP3
+// device must accept, and the size ResponseBuilder splits responses at by
+// default.
+const DefaultMaxFragmentSize = 2048
+
+// responseHeaderSize is the size of a response's application control,
+// function code and IIN.
+const responseHeaderSize = 4
+
+// ResponseBuilder builds a response from point values, split into as many
+// application fragments as it needs:
+//
+//	fragments, err := dnp3.NewResponse(dnp3.Response).Sequence(3).
+//		Points(30, 1, map[int]any{0: 12, 1: 13, 2: 14, 40: 20}).Fragments()
+//
+// The fragments have consecutive sequence numbers, FIR set on the first and
+// FIN on the last. Every fragment but the last asks for confirmation (CON),
+// which the outstation waits for before sending the next; the last one only
+// does after Confirm. Errors from any step are returned by Fragments.
+type ResponseBuilder struct {
+	response        ApplicationResponse
+	maxFragmentSize int
+	err             error
+}
+
+// NewResponse starts a response with function code fc, sequence number 0 and
+// fragments of at most DefaultMaxFragmentSize bytes.
+func NewResponse(fc ResponseFunctionCode) *ResponseBuilder {
+	return &ResponseBuilder{
+		response:        ApplicationResponse{FunctionCode: fc},
+		maxFragmentSize: DefaultMaxFragmentSize,
+	}
+}
+
+// Sequence sets the application sequence number (0-15) of the first fragment.
+func (b *ResponseBuilder) Sequence(sequence uint8) *ResponseBuilder {
+	err := b.response.SetSequence(sequence)
+	if err != nil {
+		b.fail(err)
+	}
+
+	return b
+}
+
+// IIN sets the internal indications sent in every fragment.
+func (b *ResponseBuilder) IIN(iin ApplicationInternalIndications) *ResponseBuilder {
+	b.response.InternalIndications = iin
+
+	return b
+}
+
+// Confirm asks for confirmation of the last fragment too, as a response that
+// reports events must. Unsolicited responses always ask for it.
+func (b *ResponseBuilder) Confirm() *ResponseBuilder {
+	b.response.Control.Confirm = true
+
+	return b
+}
+
+// MaxFragmentSize sets the largest fragment, in bytes including the 4-byte
+// response header, that Fragments returns.
+func (b *ResponseBuilder) MaxFragmentSize(size int) *ResponseBuilder {
+	if size <= responseHeaderSize {
+		b.fail(fmt.Errorf("max fragment size must exceed %d bytes, got %d", responseHeaderSize, size))
+
+		return b
+	}
+
+	b.maxFragmentSize = size
+
+	return b
+}
+
+// Points adds the objects NewPointObjects returns for values.
+func (b *ResponseBuilder) Points(group, variation uint8, values map[int]any) *ResponseBuilder {
+	objects, err := NewPointObjects(group, variation, values)
+	if err != nil {
+		b.fail(err)
+
+		return b
+	}
+
+	b.response.Data.Objects = append(b.response.Data.Objects, objects...)
+
+	return b
+}
+
+// Object adds a complete object as is. Objects with a start-stop range or
+// index prefixes are split across fragments if need be; others must fit in
+// one.
+func (b *ResponseBuilder) Object(object DataObject) *ResponseBuilder {
+	b.response.Data.Objects = append(b.response.Data.Objects, object)
+
+	return b
+}
+
+// Fragments returns the response split into fragments of at most the max
+// fragment size. Objects are kept in order; one that doesn't fit in the
+// space left in a fragment is split between it and the next. A response
+// without objects is a single, empty fragment. Unsolicited responses must
+// fit in one fragment.
+func (b *ResponseBuilder) Fragments() ([]*ApplicationResponse, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	room := b.maxFragmentSize - responseHeaderSize
+
+	var (
+		fragments [][]DataObject
+		current   []DataObject
+		used      int
+	)
+
+	for _, object := range b.response.Data.Objects {
+		for {
+			size, err := objectSize(object)
+			if err != nil {
+				return nil, err
+			}
+
+			if used+size <= room {
+				current = append(current, object)
+				used += size
+
+				break
+			}
+
+			head, tail, ok, err := splitObject(object, room-used)
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				current = append(current, head)
+				object = tail
+			} else if len(current) == 0 {
+				return nil, fmt.Errorf("g%dv%d object of %d bytes doesn't fit in a %d-byte fragment",
+					object.Header.Group, object.Header.Variation, size, b.maxFragmentSize)
+			}
+
+			fragments = append(fragments, current)
+			current, used = nil, 0
+		}
+	}
+
+	fragments = append(fragments, current)
+
+	unsolicited := b.response.FunctionCode == UnsolicitedResponse
+	if unsolicited && len(fragments) > 1 {
+		return nil, fmt.Errorf("unsolicited response needs %d fragments, must fit in one", len(fragments))
+	}
+
+	responses := make([]*ApplicationResponse, 0, len(fragments))
+
+	for i, objects := range fragments {
+		final := i == len(fragments)-1
+		response := b.response
+		response.Control = ApplicationControl{
+			First:       i == 0,
+			Final:       final,
+			Confirm:     !final || b.response.Control.Confirm || unsolicited,
+			Unsolicited: unsolicited,
+			Sequence:    (b.response.Control.Sequence + uint8(i%16)) % 16, //nolint:gosec // G115 - bounded by %
+		}
+		response.Data = ApplicationData{Objects: objects}
+		responses = append(responses, &response)
+	}
+
+	return responses, nil
+}
+
+func (b *ResponseBuilder) fail(err error) {
+	b.err = errors.Join(b.err, err)
+}
+
+// NewPointObjects returns group/variation objects holding values, by index,
+// in index order. Runs of consecutive indexes that are cheaper to send as a
+// start-stop range (qualifier 0x00, 0x01 or 0x02) get an object of their own;
+// the indexes around them go in objects with 1-octet index prefixes and
+// count (0x17), or 2-octet ones (0x28) if an index or the count exceeds 255.
+// Packed variations, such as Group 1 Var 1, and indexes above 65535 always
+// use start-stop ranges.
+//
+// Numbers (float64 or int) are set with SetNumeric on points that have a
+// numeric encoding, and every other value with SetValue; a nil value leaves
+// the point zero-valued. Flags and times are left zero, to be filled in on the
+// points of the returned objects.
+func NewPointObjects(group, variation uint8, values map[int]any) ([]DataObject, error) {
+	definition, ok := objectTypes[groupVariation{group, variation}]
+	if !ok {
+		return nil, fmt.Errorf("unsupported group/variation: %d/%d", group, variation)
+	}
+
+	indexes := slices.Sorted(maps.Keys(values))
+	if len(indexes) > 0 && (indexes[0] < 0 || int64(indexes[len(indexes)-1]) > math.MaxUint32) {
+		return nil, fmt.Errorf("g%dv%d: indexes must be 0 to %d", group, variation, uint32(math.MaxUint32))
+	}
+
+	_, err := definition.blankPoints(1, Index1Octet)
+	packed := err != nil
+
+	var (
+		objects []DataObject
+		sparse  []int
+	)
+
+	flush := func() error {
+		if len(sparse) == 0 {
+			return nil
+		}
+
+		object, err := newPrefixedObject(definition, group, variation, sparse, values)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, object)
+		sparse = nil
+
+		return nil
+	}
+
+	for start := 0; start < len(indexes); {
+		end := start + 1
+		for end < len(indexes) && indexes[end] == indexes[end-1]+1 {
+			end++
+		}
+
+		run := indexes[start:end]
+		start = end
+
+		if !packed && !rangeIsCheaper(run) {
+			sparse = append(sparse, run...)
+
+			continue
+		}
+
+		err = flush()
+		if err != nil {
+			return objects, err
+		}
+
+		var object DataObject
+
+		object, err = newRangeObject(definition, group, variation, run, values)
+		if err != nil {
+			return objects, err
+		}
+
+		objects = append(objects, object)
+	}
+
+	return objects, flush()
+}
+
+// rangeIsCheaper reports whether the consecutive indexes of run take fewer
+// bytes as a start-stop object than with index prefixes.
+func rangeIsCheaper(run []int) bool {
+	last := run[len(run)-1]
+	if last > math.MaxUint16 {
+		return true
+	}
+
+	prefixSize := 1
+	if last > math.MaxUint8 {
+		prefixSize = 2
+	}
+
+	// The header is group, variation and qualifier, then a start and stop
+	// index as wide as the last.
+	rangeField := NewStartStopRangeField(uint32(run[0]), uint32(last)) //nolint:gosec // G115 - checked above
+	headerSize := 3 + 2*rangeField.byteWidth
+
+	return len(run)*prefixSize > headerSize
+}
+
+// newRangeObject returns a start-stop object for the consecutive indexes.
+func newRangeObject(
+	definition *objectType,
+	group, variation uint8,
+	indexes []int,
+	values map[int]any,
+) (DataObject, error) {
+	//nolint:gosec // G115 - indexes checked by NewPointObjects
+	rangeField := NewStartStopRangeField(uint32(indexes[0]), uint32(indexes[len(indexes)-1]))
+
+	return newValuesObject(definition, ObjectHeader{
+		Group:           group,
+		Variation:       variation,
+		PointPrefixCode: NoPrefix,
+		RangeSpecCode:   rangeField.Code(),
+		RangeField:      rangeField,
+	}, indexes, values)
+}
+
+// newPrefixedObject returns an index-prefixed count object for indexes.
+func newPrefixedObject(
+	definition *objectType,
+	group, variation uint8,
+	indexes []int,
+	values map[int]any,
+) (DataObject, error) {
+	if len(indexes) > math.MaxUint16 {
+		return DataObject{}, fmt.Errorf("g%dv%d: %d indexes exceed a 2-octet count", group, variation, len(indexes))
+	}
+
+	//nolint:gosec // G115 - bounded above
+	count := uint32(len(indexes))
+	prefixCode := Index1Octet
+	rangeField := &CountRangeField{Count: count, byteWidth: 1, code: Count1}
+
+	if indexes[len(indexes)-1] > math.MaxUint8 || count > math.MaxUint8 {
+		prefixCode = Index2Octet
+		rangeField = &CountRangeField{Count: count, byteWidth: 2, code: Count2}
+	}
+
+	return newValuesObject(definition, ObjectHeader{
+		Group:           group,
+		Variation:       variation,
+		PointPrefixCode: prefixCode,
+		RangeSpecCode:   rangeField.Code(),
+		RangeField:      rangeField,
+	}, indexes, values)
+}
+
+// newValuesObject returns an object with header whose points hold the values
+// of indexes.
+func newValuesObject(
+	definition *objectType,
+	header ObjectHeader,
+	indexes []int,
+	values map[int]any,
+) (DataObject, error) {
+	header.objectType = definition
+	object := DataObject{Header: header}
+
+	points, err := definition.blankPoints(len(indexes), header.PointPrefixCode)
+	if err != nil {
+		return object, err
+	}
+
+	for i, point := range points {
+		if header.PointPrefixCode != NoPrefix {
+			err = point.SetIndex(indexes[i])
+		}
+
+		if err == nil && values[indexes[i]] != nil {
+			err = setPointValue(point, values[indexes[i]])
+		}
+
+		if err != nil {
+			return object, fmt.Errorf("g%dv%d index %d: %w", header.Group, header.Variation, indexes[i], err)
+		}
+	}
+
+	object.Points = points
+
+	return object, object.updateIndexes()
+}
+
+// setPointValue sets a point from a number, for points with a numeric
+// encoding, or else with SetValue.
+func setPointValue(point Point, value any) error {
+	if point, ok := point.(*PointBytes); ok && point.NumericEncoding() != NumericNone {
+		switch number := value.(type) {
+		case float64:
+			return point.SetNumeric(number)
+		case int:
+			return point.SetNumeric(float64(number))
+		}
+	}
+
+	return point.SetValue(value)
+}
+
+// objectSize returns the encoded size of object.
+func objectSize(object DataObject) (int, error) {
+	encoded, err := object.SerializeTo()
+	if err != nil {
+		return 0, fmt.Errorf("g%dv%d: %w", object.Header.Group, object.Header.Variation, err)
+	}
+
+	return len(encoded), nil
+}
+
+// splitObject splits the points of a start-stop or index-prefixed object in
+// two: head, the most that fit in room bytes, and tail, the rest. ok is false
+// if the object can't be split or not even one point fits.
+func splitObject(object DataObject, room int) (head, tail DataObject, ok bool, err error) {
+	points := len(object.Points)
+	if points < 2 {
+		return head, tail, false, nil
+	}
+
+	switch rangeField := object.Header.RangeField.(type) {
+	case *StartStopRangeField:
+	case *CountRangeField:
+		if object.Header.PointPrefixCode == NoPrefix || rangeField.variable {
+			return head, tail, false, nil
+		}
+	default:
+		return head, tail, false, nil
+	}
+
+	// The first count that doesn't fit; sizes only grow with count.
+	tooMany := 1 + sort.Search(points-1, func(i int) bool {
+		if err != nil {
+			return true
+		}
+
+		var size int
+
+		size, err = objectSize(partObject(object, 0, i+1))
+
+		return size > room
+	})
+	if err != nil || tooMany == 1 {
+		return head, tail, false, err
+	}
+
+	return partObject(object, 0, tooMany-1), partObject(object, tooMany-1, points), true, nil
+}
+
+// partObject returns an object with points from to to of object, its range
+// narrowed to match.
+func partObject(object DataObject, from, to int) DataObject {
+	header := object.Header
+
+	switch rangeField := header.RangeField.(type) {
+	case *StartStopRangeField:
+		part := *rangeField
+		part.Start = rangeField.Start + uint32(from) //nolint:gosec // G115 - within the range
+		part.Stop = rangeField.Start + uint32(to-1)  //nolint:gosec // G115 - within the range
+		header.RangeField = &part
+	case *CountRangeField:
+		part := *rangeField
+		part.Count = uint32(to - from) //nolint:gosec // G115 - within the count
+		header.RangeField = &part
+	}
+
+	part := DataObject{Header: header, Points: object.Points[from:to]}
+	_ = part.updateIndexes()
+
+	return part
+}
@@ -0,0 +1,1053 @@
+package dnp3
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PointDataTypeAuthentication identifies a secure authentication (Group 120)
+// object.
+const PointDataTypeAuthentication PointDataType = "authentication"
+
+// MACAlgorithm is the MAC algorithm (MAL) of a challenge or session key
+// status.
+//
+//go:generate stringer -type=MACAlgorithm -trimprefix=MAC
+type MACAlgorithm uint8
+
+const (
+	MACSHA1Truncated4    MACAlgorithm = 1 // serial
+	MACSHA1Truncated10   MACAlgorithm = 2 // networked
+	MACSHA256Truncated8  MACAlgorithm = 3 // serial
+	MACSHA256Truncated16 MACAlgorithm = 4 // networked
+	MACSHA1Truncated8    MACAlgorithm = 5 // serial
+	MACAESGMAC           MACAlgorithm = 6
+)
+
+// KeyWrapAlgorithm is the algorithm (KWA) that wraps the session keys in a
+// session key change.
+//
+//go:generate stringer -type=KeyWrapAlgorithm -trimprefix=KeyWrap
+type KeyWrapAlgorithm uint8
+
+const (
+	KeyWrapAES128 KeyWrapAlgorithm = 1
+	KeyWrapAES256 KeyWrapAlgorithm = 2
+)
+
+// KeyStatus is the session key status (KST) an outstation reports.
+//
+//go:generate stringer -type=KeyStatus -trimprefix=KeyStatus
+type KeyStatus uint8
+
+const (
+	KeyStatusOK       KeyStatus = 1
+	KeyStatusNotInit  KeyStatus = 2
+	KeyStatusCommFail KeyStatus = 3
+	KeyStatusAuthFail KeyStatus = 4
+)
+
+// ChallengeReason is the reason (RSN) for a challenge.
+//
+//go:generate stringer -type=ChallengeReason -trimprefix=ChallengeReason
+type ChallengeReason uint8
+
+const (
+	ChallengeReasonCritical ChallengeReason = 1
+)
+
+// AuthErrorCode is the error code (ERR) of an authentication error.
+//
+//go:generate stringer -type=AuthErrorCode -trimprefix=AuthError
+type AuthErrorCode uint8
+
+const (
+	AuthErrorAuthenticationFailed     AuthErrorCode = 1
+	AuthErrorAggressiveNotSupported   AuthErrorCode = 4
+	AuthErrorMACNotSupported          AuthErrorCode = 5
+	AuthErrorKeyWrapNotSupported      AuthErrorCode = 6
+	AuthErrorAuthorizationFailed      AuthErrorCode = 7
+	AuthErrorUpdateKeyMethodForbidden AuthErrorCode = 8
+	AuthErrorInvalidSignature         AuthErrorCode = 9
+	AuthErrorInvalidCertification     AuthErrorCode = 10
+	AuthErrorUnknownUser              AuthErrorCode = 11
+	AuthErrorMaxKeyStatusRequests     AuthErrorCode = 12
+)
+
+// UserStatusOperation is the operation (OP) of a user status change.
+//
+//go:generate stringer -type=UserStatusOperation -trimprefix=UserStatus
+type UserStatusOperation uint8
+
+const (
+	UserStatusAdd    UserStatusOperation = 1
+	UserStatusDelete UserStatusOperation = 2
+	UserStatusChange UserStatusOperation = 3
+)
+
+// authObject is the base of the size-prefixed Group 120 objects.
+type authObject struct {
+	sizedObject
+}
+
+func (a *authObject) DataType() PointDataType { return PointDataTypeAuthentication }
+
+// AuthChallenge (Group 120 Var 1) challenges the sender of a critical
+// message, which must answer with an AuthReply.
+type AuthChallenge struct {
+	authObject
+
+	Sequence  uint32          `json:"sequence"`
+	User      uint16          `json:"user"`
+	Algorithm MACAlgorithm    `json:"algorithm"`
+	Reason    ChallengeReason `json:"reason"`
+	Data      []byte          `json:"data"`
+}
+
+func (p *AuthChallenge) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 8 {
+		return fmt.Errorf("authentication challenge requires at least 8 bytes, got %d", len(body))
+	}
+
+	p.Sequence = binary.LittleEndian.Uint32(body[0:4])
+	p.User = binary.LittleEndian.Uint16(body[4:6])
+	p.Algorithm = MACAlgorithm(body[6])
+	p.Reason = ChallengeReason(body[7])
+	p.Data = slices.Clone(body[8:])
+
+	return nil
+}
+
+func (p *AuthChallenge) SerializeTo() ([]byte, error) {
+	return p.prefixed(p.encodeBody())
+}
+
+// encodeBody returns the object without its size prefix.
+func (p *AuthChallenge) encodeBody() []byte {
+	body := binary.LittleEndian.AppendUint32(nil, p.Sequence)
+	body = binary.LittleEndian.AppendUint16(body, p.User)
+	body = append(body, byte(p.Algorithm), byte(p.Reason))
+
+	return append(body, p.Data...)
+}
+
+func (p *AuthChallenge) String() string {
+	return strings.Join([]string{
+		fmt.Sprintf("Sequence : %d", p.Sequence),
+		fmt.Sprintf("User     : %d", p.User),
+		fmt.Sprintf("Algorithm: (%d) %s", p.Algorithm, p.Algorithm),
+		fmt.Sprintf("Reason   : (%d) %s", p.Reason, p.Reason),
+		fmt.Sprintf("Data     : 0x % X", p.Data),
+	}, "\n")
+}
+
+func (p *AuthChallenge) GetValue() any { return *p }
+
+func (p *AuthChallenge) SetValue(value any) error {
+	val, ok := value.(AuthChallenge)
+	if !ok {
+		return fmt.Errorf("authentication challenge value must be an AuthChallenge, got %T", value)
+	}
+
+	val.authObject = p.authObject
+	*p = val
+
+	return nil
+}
+
+// AuthReply (Group 120 Var 2) answers an AuthChallenge with the MAC of the
+// challenge and the challenged message.
+type AuthReply struct {
+	authObject
+
+	Sequence uint32 `json:"sequence"`
+	User     uint16 `json:"user"`
+	MAC      []byte `json:"mac"`
+}
+
+func (p *AuthReply) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 6 {
+		return fmt.Errorf("authentication reply requires at least 6 bytes, got %d", len(body))
+	}
+
+	p.Sequence = binary.LittleEndian.Uint32(body[0:4])
+	p.User = binary.LittleEndian.Uint16(body[4:6])
+	p.MAC = slices.Clone(body[6:])
+
+	return nil
+}
+
+func (p *AuthReply) SerializeTo() ([]byte, error) {
+	body := binary.LittleEndian.AppendUint32(nil, p.Sequence)
+	body = binary.LittleEndian.AppendUint16(body, p.User)
+	body = append(body, p.MAC...)
+
+	return p.prefixed(body)
+}
+
+func (p *AuthReply) String() string {
+	return strings.Join([]string{
+		fmt.Sprintf("Sequence: %d", p.Sequence),
+		fmt.Sprintf("User    : %d", p.User),
+		fmt.Sprintf("MAC     : 0x % X", p.MAC),
+	}, "\n")
+}
+
+func (p *AuthReply) GetValue() any { return *p }
+
+func (p *AuthReply) SetValue(value any) error {
+	val, ok := value.(AuthReply)
+	if !ok {
+		return fmt.Errorf("authentication reply value must be an AuthReply, got %T", value)
+	}
+
+	val.authObject = p.authObject
+	*p = val
+
+	return nil
+}
+
+// AuthAggressiveRequest (Group 120 Var 3) starts an aggressive mode request,
+// which carries its own MAC (Var 9) instead of waiting to be challenged. It
+// has a fixed size and no prefix (qualifier 0x07).
+type AuthAggressiveRequest struct {
+	authObject
+
+	Sequence uint32 `json:"sequence"`
+	User     uint16 `json:"user"`
+}
+
+func (p *AuthAggressiveRequest) DecodeFromBytes(data []byte, _ int) error {
+	if len(data) < 6 {
+		return fmt.Errorf("aggressive mode request requires 6 bytes, got %d", len(data))
+	}
+
+	p.Sequence = binary.LittleEndian.Uint32(data[0:4])
+	p.User = binary.LittleEndian.Uint16(data[4:6])
+
+	return nil
+}
+
+func (p *AuthAggressiveRequest) SerializeTo() ([]byte, error) {
+	return binary.LittleEndian.AppendUint16(binary.LittleEndian.AppendUint32(nil, p.Sequence), p.User), nil
+}
+
+func (p *AuthAggressiveRequest) String() string {
+	return fmt.Sprintf("Sequence: %d\nUser    : %d", p.Sequence, p.User)
+}
+
+func (p *AuthAggressiveRequest) Fields() PointFields { return PointFields{Value: true} }
+
+func (p *AuthAggressiveRequest) GetValue() any { return *p }
+
+func (p *AuthAggressiveRequest) SetValue(value any) error {
+	val, ok := value.(AuthAggressiveRequest)
+	if !ok {
+		return fmt.Errorf("aggressive mode request value must be an AuthAggressiveRequest, got %T", value)
+	}
+
+	*p = val
+
+	return nil
+}
+
+// AuthKeyStatusRequest (Group 120 Var 4) asks for the session key status of
+// a user. It has a fixed size and no prefix (qualifier 0x07).
+type AuthKeyStatusRequest struct {
+	authObject
+
+	User uint16 `json:"user"`
+}
+
+func (p *AuthKeyStatusRequest) DecodeFromBytes(data []byte, _ int) error {
+	if len(data) < 2 {
+		return fmt.Errorf("session key status request requires 2 bytes, got %d", len(data))
+	}
+
+	p.User = binary.LittleEndian.Uint16(data[0:2])
+
+	return nil
+}
+
+func (p *AuthKeyStatusRequest) SerializeTo() ([]byte, error) {
+	return binary.LittleEndian.AppendUint16(nil, p.User), nil
+}
+
+func (p *AuthKeyStatusRequest) String() string {
+	return fmt.Sprintf("User: %d", p.User)
+}
+
+func (p *AuthKeyStatusRequest) Fields() PointFields { return PointFields{Value: true} }
+
+func (p *AuthKeyStatusRequest) GetValue() any { return *p }
+
+func (p *AuthKeyStatusRequest) SetValue(value any) error {
+	val, ok := value.(AuthKeyStatusRequest)
+	if !ok {
+		return fmt.Errorf("session key status request value must be an AuthKeyStatusRequest, got %T", value)
+	}
+
+	*p = val
+
+	return nil
+}
+
+// AuthKeyStatus (Group 120 Var 5) reports the session key status of a user,
+// with the challenge data the next session key change must cover. After a
+// session key change it also carries the MAC that proves the new keys.
+type AuthKeyStatus struct {
+	authObject
+
+	KeySequence uint32           `json:"key_sequence"`
+	User        uint16           `json:"user"`
+	KeyWrap     KeyWrapAlgorithm `json:"key_wrap"`
+	Status      KeyStatus        `json:"status"`
+	Algorithm   MACAlgorithm     `json:"algorithm"`
+	Challenge   []byte           `json:"challenge"`
+	MAC         []byte           `json:"mac"`
+}
+
+func (p *AuthKeyStatus) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 11 {
+		return fmt.Errorf("session key status requires at least 11 bytes, got %d", len(body))
+	}
+
+	p.KeySequence = binary.LittleEndian.Uint32(body[0:4])
+	p.User = binary.LittleEndian.Uint16(body[4:6])
+	p.KeyWrap = KeyWrapAlgorithm(body[6])
+	p.Status = KeyStatus(body[7])
+	p.Algorithm = MACAlgorithm(body[8])
+
+	length := int(binary.LittleEndian.Uint16(body[9:11]))
+	if len(body) < 11+length {
+		return fmt.Errorf("session key status challenge of %d bytes, got %d", length, len(body)-11)
+	}
+
+	p.Challenge = slices.Clone(body[11 : 11+length])
+	p.MAC = slices.Clone(body[11+length:])
+
+	return nil
+}
+
+func (p *AuthKeyStatus) SerializeTo() ([]byte, error) {
+	return p.prefixed(p.encodeBody())
+}
+
+// encodeBody returns the object without its size prefix.
+func (p *AuthKeyStatus) encodeBody() []byte {
+	body := binary.LittleEndian.AppendUint32(nil, p.KeySequence)
+	body = binary.LittleEndian.AppendUint16(body, p.User)
+	body = append(body, byte(p.KeyWrap), byte(p.Status), byte(p.Algorithm))
+	//nolint:gosec // G115 - objects are bounded by their size prefix
+	body = binary.LittleEndian.AppendUint16(body, uint16(len(p.Challenge)))
+	body = append(body, p.Challenge...)
+
+	return append(body, p.MAC...)
+}
+
+func (p *AuthKeyStatus) String() string {
+	return strings.Join([]string{
+		fmt.Sprintf("Key Sequence: %d", p.KeySequence),
+		fmt.Sprintf("User        : %d", p.User),
+		fmt.Sprintf("Key Wrap    : (%d) %s", p.KeyWrap, p.KeyWrap),
+		fmt.Sprintf("Status      : (%d) %s", p.Status, p.Status),
+		fmt.Sprintf("Algorithm   : (%d) %s", p.Algorithm, p.Algorithm),
+		fmt.Sprintf("Challenge   : 0x % X", p.Challenge),
+		fmt.Sprintf("MAC         : 0x % X", p.MAC),
+	}, "\n")
+}
+
+func (p *AuthKeyStatus) GetValue() any { return *p }
+
+func (p *AuthKeyStatus) SetValue(value any) error {
+	val, ok := value.(AuthKeyStatus)
+	if !ok {
+		return fmt.Errorf("session key status value must be an AuthKeyStatus, got %T", value)
+	}
+
+	val.authObject = p.authObject
+	*p = val
+
+	return nil
+}
+
+// AuthKeyChange (Group 120 Var 6) sets new session keys, wrapped with the
+// user's update key.
+type AuthKeyChange struct {
+	authObject
+
+	KeySequence uint32 `json:"key_sequence"`
+	User        uint16 `json:"user"`
+	WrappedKeys []byte `json:"wrapped_keys"`
+}
+
+func (p *AuthKeyChange) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 6 {
+		return fmt.Errorf("session key change requires at least 6 bytes, got %d", len(body))
+	}
+
+	p.KeySequence = binary.LittleEndian.Uint32(body[0:4])
+	p.User = binary.LittleEndian.Uint16(body[4:6])
+	p.WrappedKeys = slices.Clone(body[6:])
+
+	return nil
+}
+
+func (p *AuthKeyChange) SerializeTo() ([]byte, error) {
+	return p.prefixed(p.encodeBody())
+}
+
+// encodeBody returns the object without its size prefix.
+func (p *AuthKeyChange) encodeBody() []byte {
+	body := binary.LittleEndian.AppendUint32(nil, p.KeySequence)
+	body = binary.LittleEndian.AppendUint16(body, p.User)
+
+	return append(body, p.WrappedKeys...)
+}
+
+func (p *AuthKeyChange) String() string {
+	return strings.Join([]string{
+		fmt.Sprintf("Key Sequence: %d", p.KeySequence),
+		fmt.Sprintf("User        : %d", p.User),
+		fmt.Sprintf("Wrapped Keys: %d bytes", len(p.WrappedKeys)),
+	}, "\n")
+}
+
+func (p *AuthKeyChange) GetValue() any { return *p }
+
+func (p *AuthKeyChange) SetValue(value any) error {
+	val, ok := value.(AuthKeyChange)
+	if !ok {
+		return fmt.Errorf("session key change value must be an AuthKeyChange, got %T", value)
+	}
+
+	val.authObject = p.authObject
+	*p = val
+
+	return nil
+}
+
+// AuthError (Group 120 Var 7) reports an authentication failure.
+type AuthError struct {
+	authObject
+
+	Sequence    uint32        `json:"sequence"`
+	User        uint16        `json:"user"`
+	Association uint16        `json:"association"`
+	Code        AuthErrorCode `json:"code"`
+	Time        AbsoluteTime  `json:"time"`
+	Text        string        `json:"text"`
+}
+
+func (p *AuthError) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 15 {
+		return fmt.Errorf("authentication error requires at least 15 bytes, got %d", len(body))
+	}
+
+	p.Sequence = binary.LittleEndian.Uint32(body[0:4])
+	p.User = binary.LittleEndian.Uint16(body[4:6])
+	p.Association = binary.LittleEndian.Uint16(body[6:8])
+	p.Code = AuthErrorCode(body[8])
+
+	p.Time, err = BytesToDNP3TimeAbsolute(body[9:15])
+	if err != nil {
+		return fmt.Errorf("time of error: %w", err)
+	}
+
+	p.Text = string(body[15:])
+
+	return nil
+}
+
+func (p *AuthError) SerializeTo() ([]byte, error) {
+	errorTime, err := TimeAbsoluteToBytes(p.Time)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode time of error: %w", err)
+	}
+
+	body := binary.LittleEndian.AppendUint32(nil, p.Sequence)
+	body = binary.LittleEndian.AppendUint16(body, p.User)
+	body = binary.LittleEndian.AppendUint16(body, p.Association)
+	body = append(body, byte(p.Code))
+	body = append(body, errorTime...)
+	body = append(body, p.Text...)
+
+	return p.prefixed(body)
+}
+
+func (p *AuthError) String() string {
+	parts := []string{
+		fmt.Sprintf("Sequence   : %d", p.Sequence),
+		fmt.Sprintf("User       : %d", p.User),
+		fmt.Sprintf("Association: %d", p.Association),
+		fmt.Sprintf("Code       : (%d) %s", p.Code, p.Code),
+		"Time       : " + p.Time.String(),
+	}
+
+	if p.Text != "" {
+		parts = append(parts, "Text       : "+p.Text)
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+func (p *AuthError) GetValue() any { return *p }
+
+func (p *AuthError) SetValue(value any) error {
+	val, ok := value.(AuthError)
+	if !ok {
+		return fmt.Errorf("authentication error value must be an AuthError, got %T", value)
+	}
+
+	val.authObject = p.authObject
+	*p = val
+
+	return nil
+}
+
+// AuthUserCertificate (Group 120 Var 8) carries a user certificate for
+// asymmetric update key changes.
+type AuthUserCertificate struct {
+	authObject
+
+	KeyChangeMethod uint8  `json:"key_change_method"`
+	CertificateType uint8  `json:"certificate_type"`
+	Certificate     []byte `json:"certificate"`
+}
+
+func (p *AuthUserCertificate) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 2 {
+		return fmt.Errorf("user certificate requires at least 2 bytes, got %d", len(body))
+	}
+
+	p.KeyChangeMethod = body[0]
+	p.CertificateType = body[1]
+	p.Certificate = slices.Clone(body[2:])
+
+	return nil
+}
+
+func (p *AuthUserCertificate) SerializeTo() ([]byte, error) {
+	return p.prefixed(append([]byte{p.KeyChangeMethod, p.CertificateType}, p.Certificate...))
+}
+
+func (p *AuthUserCertificate) String() string {
+	return strings.Join([]string{
+		fmt.Sprintf("Key Change Method: %d", p.KeyChangeMethod),
+		fmt.Sprintf("Certificate Type : %d", p.CertificateType),
+		fmt.Sprintf("Certificate      : %d bytes", len(p.Certificate)),
+	}, "\n")
+}
+
+func (p *AuthUserCertificate) GetValue() any { return *p }
+
+func (p *AuthUserCertificate) SetValue(value any) error {
+	val, ok := value.(AuthUserCertificate)
+	if !ok {
+		return fmt.Errorf("user certificate value must be an AuthUserCertificate, got %T", value)
+	}
+
+	val.authObject = p.authObject
+	*p = val
+
+	return nil
+}
+
+// AuthMAC (Group 120 Var 9) is the MAC that ends an aggressive mode request.
+type AuthMAC struct {
+	authObject
+
+	MAC []byte `json:"mac"`
+}
+
+func (p *AuthMAC) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	p.MAC = slices.Clone(body)
+
+	return nil
+}
+
+func (p *AuthMAC) SerializeTo() ([]byte, error) {
+	return p.prefixed(p.MAC)
+}
+
+func (p *AuthMAC) String() string {
+	return fmt.Sprintf("MAC: 0x % X", p.MAC)
+}
+
+// GetValue returns the MAC.
+func (p *AuthMAC) GetValue() any { return slices.Clone(p.MAC) }
+
+// SetValue sets the MAC from a []byte.
+func (p *AuthMAC) SetValue(value any) error {
+	val, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("MAC value must be []byte, got %T", value)
+	}
+
+	p.MAC = slices.Clone(val)
+
+	return nil
+}
+
+// AuthUserStatusChange (Group 120 Var 10) adds, changes or deletes a user at
+// the outstation, as authorised by the authority that certifies it.
+type AuthUserStatusChange struct {
+	authObject
+
+	KeyChangeMethod   uint8               `json:"key_change_method"`
+	Operation         UserStatusOperation `json:"operation"`
+	StatusSequence    uint32              `json:"status_sequence"`
+	UserRole          uint16              `json:"user_role"`
+	UserRoleExpiry    uint16              `json:"user_role_expiry"`
+	UserName          string              `json:"user_name"`
+	UserPublicKey     []byte              `json:"user_public_key"`
+	CertificationData []byte              `json:"certification_data"`
+}
+
+func (p *AuthUserStatusChange) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 16 {
+		return fmt.Errorf("user status change requires at least 16 bytes, got %d", len(body))
+	}
+
+	p.KeyChangeMethod = body[0]
+	p.Operation = UserStatusOperation(body[1])
+	p.StatusSequence = binary.LittleEndian.Uint32(body[2:6])
+	p.UserRole = binary.LittleEndian.Uint16(body[6:8])
+	p.UserRoleExpiry = binary.LittleEndian.Uint16(body[8:10])
+
+	fields, err := splitAuthFields(body[16:],
+		binary.LittleEndian.Uint16(body[10:12]),
+		binary.LittleEndian.Uint16(body[12:14]),
+		binary.LittleEndian.Uint16(body[14:16]))
+	if err != nil {
+		return fmt.Errorf("user status change: %w", err)
+	}
+
+	p.UserName = string(fields[0])
+	p.UserPublicKey = fields[1]
+	p.CertificationData = fields[2]
+
+	return nil
+}
+
+func (p *AuthUserStatusChange) SerializeTo() ([]byte, error) {
+	body := []byte{p.KeyChangeMethod, byte(p.Operation)}
+	body = binary.LittleEndian.AppendUint32(body, p.StatusSequence)
+	body = binary.LittleEndian.AppendUint16(body, p.UserRole)
+	body = binary.LittleEndian.AppendUint16(body, p.UserRoleExpiry)
+	//nolint:gosec // G115 - objects are bounded by their size prefix
+	body = binary.LittleEndian.AppendUint16(body, uint16(len(p.UserName)))
+	//nolint:gosec // G115 - objects are bounded by their size prefix
+	body = binary.LittleEndian.AppendUint16(body, uint16(len(p.UserPublicKey)))
+	//nolint:gosec // G115 - objects are bounded by their size prefix
+	body = binary.LittleEndian.AppendUint16(body, uint16(len(p.CertificationData)))
+	body = append(body, p.UserName...)
+	body = append(body, p.UserPublicKey...)
+	body = append(body, p.CertificationData...)
+
+	return p.prefixed(body)
+}
+
+func (p *AuthUserStatusChange) String() string {
+	return strings.Join([]string{
+		fmt.Sprintf("Key Change Method : %d", p.KeyChangeMethod),
+		fmt.Sprintf("Operation         : (%d) %s", p.Operation, p.Operation),
+		fmt.Sprintf("Status Sequence   : %d", p.StatusSequence),
+		fmt.Sprintf("User Role         : %d", p.UserRole),
+		fmt.Sprintf("User Role Expiry  : %d days", p.UserRoleExpiry),
+		"User Name         : " + p.UserName,
+		fmt.Sprintf("User Public Key   : %d bytes", len(p.UserPublicKey)),
+		fmt.Sprintf("Certification Data: %d bytes", len(p.CertificationData)),
+	}, "\n")
+}
+
+func (p *AuthUserStatusChange) GetValue() any { return *p }
+
+func (p *AuthUserStatusChange) SetValue(value any) error {
+	val, ok := value.(AuthUserStatusChange)
+	if !ok {
+		return fmt.Errorf("user status change value must be an AuthUserStatusChange, got %T", value)
+	}
+
+	val.authObject = p.authObject
+	*p = val
+
+	return nil
+}
+
+// AuthUpdateKeyChangeRequest (Group 120 Var 11) starts an update key change
+// for a user, with the master's challenge data.
+type AuthUpdateKeyChangeRequest struct {
+	authObject
+
+	KeyChangeMethod uint8  `json:"key_change_method"`
+	UserName        string `json:"user_name"`
+	Challenge       []byte `json:"challenge"`
+}
+
+func (p *AuthUpdateKeyChangeRequest) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 5 {
+		return fmt.Errorf("update key change request requires at least 5 bytes, got %d", len(body))
+	}
+
+	p.KeyChangeMethod = body[0]
+
+	fields, err := splitAuthFields(body[5:],
+		binary.LittleEndian.Uint16(body[1:3]),
+		binary.LittleEndian.Uint16(body[3:5]))
+	if err != nil {
+		return fmt.Errorf("update key change request: %w", err)
+	}
+
+	p.UserName = string(fields[0])
+	p.Challenge = fields[1]
+
+	return nil
+}
+
+func (p *AuthUpdateKeyChangeRequest) SerializeTo() ([]byte, error) {
+	body := []byte{p.KeyChangeMethod}
+	//nolint:gosec // G115 - objects are bounded by their size prefix
+	body = binary.LittleEndian.AppendUint16(body, uint16(len(p.UserName)))
+	//nolint:gosec // G115 - objects are bounded by their size prefix
+	body = binary.LittleEndian.AppendUint16(body, uint16(len(p.Challenge)))
+	body = append(body, p.UserName...)
+	body = append(body, p.Challenge...)
+
+	return p.prefixed(body)
+}
+
+func (p *AuthUpdateKeyChangeRequest) String() string {
+	return strings.Join([]string{
+		fmt.Sprintf("Key Change Method: %d", p.KeyChangeMethod),
+		"User Name        : " + p.UserName,
+		fmt.Sprintf("Challenge        : 0x % X", p.Challenge),
+	}, "\n")
+}
+
+func (p *AuthUpdateKeyChangeRequest) GetValue() any { return *p }
+
+func (p *AuthUpdateKeyChangeRequest) SetValue(value any) error {
+	val, ok := value.(AuthUpdateKeyChangeRequest)
+	if !ok {
+		return fmt.Errorf("update key change request value must be an AuthUpdateKeyChangeRequest, got %T", value)
+	}
+
+	val.authObject = p.authObject
+	*p = val
+
+	return nil
+}
+
+// AuthUpdateKeyChangeReply (Group 120 Var 12) answers an update key change
+// request with the user number assigned and the outstation's challenge data.
+type AuthUpdateKeyChangeReply struct {
+	authObject
+
+	KeySequence uint32 `json:"key_sequence"`
+	User        uint16 `json:"user"`
+	Challenge   []byte `json:"challenge"`
+}
+
+func (p *AuthUpdateKeyChangeReply) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 8 {
+		return fmt.Errorf("update key change reply requires at least 8 bytes, got %d", len(body))
+	}
+
+	p.KeySequence = binary.LittleEndian.Uint32(body[0:4])
+	p.User = binary.LittleEndian.Uint16(body[4:6])
+
+	fields, err := splitAuthFields(body[8:], binary.LittleEndian.Uint16(body[6:8]))
+	if err != nil {
+		return fmt.Errorf("update key change reply: %w", err)
+	}
+
+	p.Challenge = fields[0]
+
+	return nil
+}
+
+func (p *AuthUpdateKeyChangeReply) SerializeTo() ([]byte, error) {
+	body := binary.LittleEndian.AppendUint32(nil, p.KeySequence)
+	body = binary.LittleEndian.AppendUint16(body, p.User)
+	//nolint:gosec // G115 - objects are bounded by their size prefix
+	body = binary.LittleEndian.AppendUint16(body, uint16(len(p.Challenge)))
+	body = append(body, p.Challenge...)
+
+	return p.prefixed(body)
+}
+
+func (p *AuthUpdateKeyChangeReply) String() string {
+	return strings.Join([]string{
+		fmt.Sprintf("Key Sequence: %d", p.KeySequence),
+		fmt.Sprintf("User        : %d", p.User),
+		fmt.Sprintf("Challenge   : 0x % X", p.Challenge),
+	}, "\n")
+}
+
+func (p *AuthUpdateKeyChangeReply) GetValue() any { return *p }
+
+func (p *AuthUpdateKeyChangeReply) SetValue(value any) error {
+	val, ok := value.(AuthUpdateKeyChangeReply)
+	if !ok {
+		return fmt.Errorf("update key change reply value must be an AuthUpdateKeyChangeReply, got %T", value)
+	}
+
+	val.authObject = p.authObject
+	*p = val
+
+	return nil
+}
+
+// AuthUpdateKeyChange (Group 120 Var 13) sends a user's new update key,
+// encrypted for the outstation.
+type AuthUpdateKeyChange struct {
+	authObject
+
+	KeySequence  uint32 `json:"key_sequence"`
+	User         uint16 `json:"user"`
+	EncryptedKey []byte `json:"encrypted_key"`
+}
+
+func (p *AuthUpdateKeyChange) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	if len(body) < 8 {
+		return fmt.Errorf("update key change requires at least 8 bytes, got %d", len(body))
+	}
+
+	p.KeySequence = binary.LittleEndian.Uint32(body[0:4])
+	p.User = binary.LittleEndian.Uint16(body[4:6])
+
+	fields, err := splitAuthFields(body[8:], binary.LittleEndian.Uint16(body[6:8]))
+	if err != nil {
+		return fmt.Errorf("update key change: %w", err)
+	}
+
+	p.EncryptedKey = fields[0]
+
+	return nil
+}
+
+func (p *AuthUpdateKeyChange) SerializeTo() ([]byte, error) {
+	body := binary.LittleEndian.AppendUint32(nil, p.KeySequence)
+	body = binary.LittleEndian.AppendUint16(body, p.User)
+	//nolint:gosec // G115 - objects are bounded by their size prefix
+	body = binary.LittleEndian.AppendUint16(body, uint16(len(p.EncryptedKey)))
+	body = append(body, p.EncryptedKey...)
+
+	return p.prefixed(body)
+}
+
+func (p *AuthUpdateKeyChange) String() string {
+	return strings.Join([]string{
+		fmt.Sprintf("Key Sequence : %d", p.KeySequence),
+		fmt.Sprintf("User         : %d", p.User),
+		fmt.Sprintf("Encrypted Key: %d bytes", len(p.EncryptedKey)),
+	}, "\n")
+}
+
+func (p *AuthUpdateKeyChange) GetValue() any { return *p }
+
+func (p *AuthUpdateKeyChange) SetValue(value any) error {
+	val, ok := value.(AuthUpdateKeyChange)
+	if !ok {
+		return fmt.Errorf("update key change value must be an AuthUpdateKeyChange, got %T", value)
+	}
+
+	val.authObject = p.authObject
+	*p = val
+
+	return nil
+}
+
+// AuthUpdateKeySignature (Group 120 Var 14) is the digital signature that
+// follows an asymmetric update key change.
+type AuthUpdateKeySignature struct {
+	authObject
+
+	Signature []byte `json:"signature"`
+}
+
+func (p *AuthUpdateKeySignature) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	p.Signature = slices.Clone(body)
+
+	return nil
+}
+
+func (p *AuthUpdateKeySignature) SerializeTo() ([]byte, error) {
+	return p.prefixed(p.Signature)
+}
+
+func (p *AuthUpdateKeySignature) String() string {
+	return fmt.Sprintf("Signature: %d bytes", len(p.Signature))
+}
+
+// GetValue returns the signature.
+func (p *AuthUpdateKeySignature) GetValue() any { return slices.Clone(p.Signature) }
+
+// SetValue sets the signature from a []byte.
+func (p *AuthUpdateKeySignature) SetValue(value any) error {
+	val, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("signature value must be []byte, got %T", value)
+	}
+
+	p.Signature = slices.Clone(val)
+
+	return nil
+}
+
+// AuthUpdateKeyConfirmation (Group 120 Var 15) is the MAC with which the
+// master and the outstation each prove they hold the new update key.
+type AuthUpdateKeyConfirmation struct {
+	authObject
+
+	MAC []byte `json:"mac"`
+}
+
+func (p *AuthUpdateKeyConfirmation) DecodeFromBytes(data []byte, prefSize int) error {
+	body, err := p.body(data, prefSize)
+	if err != nil {
+		return err
+	}
+
+	p.MAC = slices.Clone(body)
+
+	return nil
+}
+
+func (p *AuthUpdateKeyConfirmation) SerializeTo() ([]byte, error) {
+	return p.prefixed(p.MAC)
+}
+
+func (p *AuthUpdateKeyConfirmation) String() string {
+	return fmt.Sprintf("MAC: 0x % X", p.MAC)
+}
+
+// GetValue returns the MAC.
+func (p *AuthUpdateKeyConfirmation) GetValue() any { return slices.Clone(p.MAC) }
+
+// SetValue sets the MAC from a []byte.
+func (p *AuthUpdateKeyConfirmation) SetValue(value any) error {
+	val, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("MAC value must be []byte, got %T", value)
+	}
+
+	p.MAC = slices.Clone(val)
+
+	return nil
+}
+
+// splitAuthFields splits data into consecutive fields of the given lengths,
+// as read from an object's length fields.
+func splitAuthFields(data []byte, lengths ...uint16) ([][]byte, error) {
+	fields := make([][]byte, 0, len(lengths))
+
+	for _, length := range lengths {
+		if len(data) < int(length) {
+			return nil, fmt.Errorf("field of %d bytes, got %d", length, len(data))
+		}
+
+		fields = append(fields, slices.Clone(data[:length]))
+		data = data[length:]
+	}
+
+	return fields, nil
+}
+
+// --- Constructor function ---
+
+// makeFixedAuthConstructor returns the constructor of the fixed-size Group
+// 120 objects, which have neither an index nor a size prefix.
+func makeFixedAuthConstructor[T any, P interface {
+	*T
+	Point
+}](width int) PointsConstructor {
+	return func(data []byte, num, _ int, prefCode PointPrefixCode) ([]Point, int, error) {
+		if prefCode != NoPrefix {
+			return nil, 0, fmt.Errorf("object can't use point prefix code %s", prefCode)
+		}
+
+		size := num * width
+		if len(data) < size {
+			return nil, 0, fmt.Errorf("not enough bytes for %d %d-byte objects", num, width)
+		}
+
+		pointsOut := make([]Point, 0, num)
+
+		for i := range num {
+			point := P(new(T))
+
+			err := point.DecodeFromBytes(data[i*width:(i+1)*width], 0)
+			if err != nil {
+				return pointsOut, size, fmt.Errorf("could not decode object: %w", err)
+			}
+
+			pointsOut = append(pointsOut, point)
+		}
+
+		return pointsOut, size, nil
+	}
+}
@@ -1,6 +1,7 @@
 package dnp3
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -35,7 +36,13 @@ func NewTransportFromBytes(data []byte) (*Transport, []byte, error) {
 }
 
 func (trans *Transport) DecodeFromBytes(data []byte) ([]byte, error) {
-	crcs, clean, err := RemoveDNP3CRCs(data)
+	return trans.decodeWithOptions(data, strictDecoding)
+}
+
+// decodeWithOptions is DecodeFromBytes with opts. Without
+// opts.Strict, blocks with a bad CRC are kept.
+func (trans *Transport) decodeWithOptions(data []byte, opts DecodeOptions) ([]byte, error) {
+	crcs, clean, err := removeDNP3CRCs(data, opts)
 	if err != nil {
 		return nil, fmt.Errorf("can't remove crcs: %w", err)
 	}
@@ -68,6 +75,65 @@ func (trans *Transport) ToByte() (byte, error) {
 	return transportByte, nil
 }
 
+// MaxSegmentSize is the largest number of application bytes one transport
+// segment can carry: a DataLink.Length of 255 less the 5 header octets it
+// counts and the transport header byte.
+const MaxSegmentSize = 249
+
+// SegmentApplication encodes app and splits it into as many frames as needed,
+// each carrying at most MaxSegmentSize application bytes. Every frame copies
+// dl (with Length recomputed), and transport sequence numbers count up from
+// startSeq, wrapping at 64. FIR is set on the first frame and FIN on the last.
+// When the fragment fits in one frame, that frame carries app itself;
+// otherwise the frames carry raw Segment bytes.
+func SegmentApplication(dl DataLink, app Application, startSeq uint8) ([]*Frame, error) {
+	if app == nil {
+		return nil, errors.New("can't segment a nil application")
+	}
+
+	if startSeq > 63 {
+		return nil, fmt.Errorf("transport sequence number %d exceeds 6 bits", startSeq)
+	}
+
+	data, err := app.SerializeTo()
+	if err != nil {
+		return nil, fmt.Errorf("error encoding application data: %w", err)
+	}
+
+	if len(data) <= MaxSegmentSize {
+		frame := &Frame{
+			DataLink:    dl,
+			Transport:   Transport{First: true, Final: true, Sequence: startSeq},
+			Application: app,
+		}
+		frame.DataLink.Length = uint16(6 + len(data)) // #nosec G115 -- bounded by MaxSegmentSize
+
+		return []*Frame{frame}, nil
+	}
+
+	frames := make([]*Frame, 0, (len(data)+MaxSegmentSize-1)/MaxSegmentSize)
+	seq := startSeq
+
+	for offset := 0; offset < len(data); offset += MaxSegmentSize {
+		end := min(offset+MaxSegmentSize, len(data))
+		frame := &Frame{
+			DataLink: dl,
+			Transport: Transport{
+				First:    offset == 0,
+				Final:    end == len(data),
+				Sequence: seq,
+			},
+			Segment: data[offset:end],
+		}
+		frame.DataLink.Length = uint16(6 + end - offset) // #nosec G115 -- bounded by MaxSegmentSize
+
+		frames = append(frames, frame)
+		seq = (seq + 1) & 0b00111111
+	}
+
+	return frames, nil
+}
+
 func (trans *Transport) String() string {
 	return fmt.Sprintf(`Transport:
 	FIN: %t
@@ -0,0 +1,151 @@
+package dnp3
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors returned by TransportReassembler.Push when a segment is
+// discarded.
+var (
+	ErrTransportNoFirst  = errors.New("transport segment received without a preceding FIR")
+	ErrTransportSequence = errors.New("transport segment out of sequence")
+	ErrTransportOverflow = errors.New("reassembled fragment exceeds maximum size")
+)
+
+// TransportReassembler rebuilds application fragments that the transport
+// layer has split across several link frames. Segments are tracked per
+// (source, destination) link address pair, so one reassembler can be fed
+// every frame of a capture or a shared connection.
+type TransportReassembler struct {
+	// MaxFragmentSize bounds the number of application bytes buffered for a
+	// single fragment. Zero means no limit.
+	MaxFragmentSize int
+	pending         map[transportKey]*transportFragment
+	// accepted is the sequence number of the last segment accepted from each
+	// link address pair, to recognise a repeated segment.
+	accepted map[transportKey]uint8
+}
+
+// transportKey identifies one direction of one link association.
+type transportKey struct {
+	Source      uint16
+	Destination uint16
+}
+
+// transportFragment is a partially reassembled application fragment.
+type transportFragment struct {
+	nextSequence uint8
+	direction    bool
+	data         []byte
+}
+
+// NewTransportReassembler returns a TransportReassembler with no size limit
+// and no segments pending.
+func NewTransportReassembler() *TransportReassembler {
+	return &TransportReassembler{
+		pending:  make(map[transportKey]*transportFragment),
+		accepted: make(map[transportKey]uint8),
+	}
+}
+
+// Push adds the transport segment carried by frame. It returns the decoded
+// Application once the FIN segment completes a fragment, and nil while more
+// segments are needed. A segment without a FIR that repeats the sequence number
+// of the last one accepted, as after a data link retry, is ignored. Segments
+// that arrive without a FIR or with any other unexpected sequence number
+// discard the pending fragment and return an error wrapping
+// ErrTransportNoFirst or ErrTransportSequence; the reassembler is then ready
+// for the next FIR.
+//
+//nolint:ireturn // the concrete type depends on the DIR bit of the frames.
+func (tr *TransportReassembler) Push(frame *Frame) (Application, error) {
+	if tr.pending == nil {
+		tr.pending = make(map[transportKey]*transportFragment)
+	}
+
+	if tr.accepted == nil {
+		tr.accepted = make(map[transportKey]uint8)
+	}
+
+	payload, err := frame.transportPayload()
+	if err != nil {
+		return nil, err
+	}
+
+	// Link-layer only frames (e.g. link status) carry no transport segment.
+	if frame.Application == nil && len(payload) == 0 {
+		return nil, nil //nolint:nilnil // nothing to reassemble
+	}
+
+	key := transportKey{Source: frame.DataLink.Source, Destination: frame.DataLink.Destination}
+	trans := frame.Transport
+
+	if last, ok := tr.accepted[key]; ok && !trans.First && trans.Sequence == last {
+		return nil, nil //nolint:nilnil // a repeated segment adds nothing
+	}
+
+	if trans.First {
+		// A new FIR always starts over, abandoning any unfinished fragment.
+		delete(tr.pending, key)
+
+		if trans.Final && frame.Application != nil {
+			tr.accepted[key] = trans.Sequence
+
+			return frame.Application, nil
+		}
+
+		tr.pending[key] = &transportFragment{direction: frame.DataLink.Control.Direction}
+	}
+
+	fragment, ok := tr.pending[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: source %d, destination %d, sequence %d",
+			ErrTransportNoFirst, key.Source, key.Destination, trans.Sequence)
+	}
+
+	if !trans.First && trans.Sequence != fragment.nextSequence {
+		delete(tr.pending, key)
+		delete(tr.accepted, key)
+
+		return nil, fmt.Errorf("%w: expected %d, got %d",
+			ErrTransportSequence, fragment.nextSequence, trans.Sequence)
+	}
+
+	fragment.data = append(fragment.data, payload...)
+	fragment.nextSequence = (trans.Sequence + 1) & 0b00111111
+	tr.accepted[key] = trans.Sequence
+
+	if tr.MaxFragmentSize > 0 && len(fragment.data) > tr.MaxFragmentSize {
+		delete(tr.pending, key)
+
+		return nil, fmt.Errorf("%w: %d > %d bytes",
+			ErrTransportOverflow, len(fragment.data), tr.MaxFragmentSize)
+	}
+
+	if !trans.Final {
+		return nil, nil //nolint:nilnil // more segments are needed
+	}
+
+	delete(tr.pending, key)
+
+	app := newApplication(fragment.direction)
+
+	err = app.DecodeFromBytes(fragment.data)
+	if err != nil {
+		return nil, fmt.Errorf("error in reassembled DNP3 Application layer: %w", err)
+	}
+
+	return app, nil
+}
+
+// Pending reports how many fragments are partially reassembled.
+func (tr *TransportReassembler) Pending() int {
+	return len(tr.pending)
+}
+
+// Reset discards every partially reassembled fragment.
+func (tr *TransportReassembler) Reset() {
+	clear(tr.pending)
+	clear(tr.accepted)
+}
@@ -135,6 +135,12 @@ func InsertDNP3CRCs(data []byte) []byte {
 // InsertDNP3CRCs, this is used in the DecodeFromBytes function to remove
 // CRCs inserted by the Transport layer and get the raw application bytes.
 func RemoveDNP3CRCs(data []byte) ([][]byte, []byte, error) {
+	return removeDNP3CRCs(data, strictDecoding)
+}
+
+// removeDNP3CRCs is RemoveDNP3CRCs with opts. Without opts.Strict, blocks
+// with a bad CRC are kept.
+func removeDNP3CRCs(data []byte, opts DecodeOptions) ([][]byte, []byte, error) {
 	const (
 		blockSize = 16
 		crcSize   = 2
@@ -152,9 +158,12 @@ func RemoveDNP3CRCs(data []byte) ([][]byte, []byte, error) {
 
 		calc := CalculateDNP3CRC(block)
 		if !slices.Equal(crc, calc) {
-			return nil, nil, fmt.Errorf(
+			err := opts.tolerate("transport", data[i:end], fmt.Errorf(
 				"crc not correct for block: %X. Got %X, expected %X",
-				block, crc, calc)
+				block, crc, calc))
+			if err != nil {
+				return nil, nil, err
+			}
 		}
 
 		clean = append(clean, block...)
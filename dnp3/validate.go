@@ -0,0 +1,448 @@
+package dnp3
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Severity ranks a Violation.
+//
+//go:generate stringer -type=Severity -trimprefix=Severity
+type Severity uint8
+
+const (
+	// SeverityWarning marks messages that are legal but unusual, and that
+	// devices are expected to tolerate.
+	SeverityWarning Severity = iota + 1
+	// SeverityError marks messages a device must reject.
+	SeverityError
+)
+
+// RuleID names a Validate rule. The rules follow the checks of the DNP3
+// AN2013-004b validation guide, grouped by layer: DL for the data link and
+// AL for the application layer.
+type RuleID string
+
+const (
+	// RuleLinkFunctionCode: the link function code is undefined, doesn't
+	// match the PRM bit, or has the wrong FCV.
+	RuleLinkFunctionCode RuleID = "DL-FC"
+	// RuleLinkReserved: a secondary frame has the reserved bit (FCB) set.
+	RuleLinkReserved RuleID = "DL-RES"
+	// RuleLinkUserData: a frame carries transport data without a user data
+	// link function code.
+	RuleLinkUserData RuleID = "DL-DATA"
+	// RuleFunctionCode: the application function code is undefined.
+	RuleFunctionCode RuleID = "AL-FC"
+	// RuleFunctionDirection: a request from an outstation, or a response
+	// from a master (the DIR bit).
+	RuleFunctionDirection RuleID = "AL-DIR"
+	// RuleApplicationControl: FIR, FIN, CON or UNS don't suit the function
+	// code.
+	RuleApplicationControl RuleID = "AL-CTL"
+	// RuleObjectUnknown: the group/variation is undefined.
+	RuleObjectUnknown RuleID = "AL-OBJ"
+	// RuleObjectFunction: the group/variation isn't allowed with the
+	// function code.
+	RuleObjectFunction RuleID = "AL-OBJ-FC"
+	// RuleQualifier: the qualifier isn't allowed with the function code.
+	RuleQualifier RuleID = "AL-QUAL"
+	// RuleObjectValues: a request that only names points (such as a Read)
+	// carries point values.
+	RuleObjectValues RuleID = "AL-VAL"
+	// RuleReserved: the qualifier reserved bit is set, the qualifier uses a
+	// reserved point prefix or range specifier code, or a CROB or analog
+	// output block status has its reserved bit set.
+	RuleReserved RuleID = "AL-RES"
+	// RuleIIN: the reserved IIN 2.6 or 2.7 is set.
+	RuleIIN RuleID = "AL-IIN"
+	// RuleRange: a start-stop range starts after it stops.
+	RuleRange RuleID = "AL-RANGE"
+	// RuleIndexOrder: the index prefixes of a static object don't strictly
+	// increase.
+	RuleIndexOrder RuleID = "AL-IDX"
+)
+
+// Violation is a rule a frame breaks.
+type Violation struct {
+	Rule     RuleID   `json:"rule"`
+	Severity Severity `json:"severity"`
+	// Object is the position in the application data of the object at fault,
+	// or -1 if the violation isn't about an object.
+	Object  int    `json:"object"`
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Object < 0 {
+		return fmt.Sprintf("%s %s: %s", v.Severity, v.Rule, v.Message)
+	}
+
+	return fmt.Sprintf("%s %s: object %d: %s", v.Severity, v.Rule, v.Object, v.Message)
+}
+
+// Validate checks a decoded (or built) frame against the semantic rules of
+// the AN2013-004b validation guide that decoding doesn't enforce: function
+// code and direction, the objects and qualifiers allowed with each function
+// code, reserved bits, and index order. It returns every violation found, in
+// frame order, or nil for a valid frame. The application layer is only
+// checked when the frame holds a complete fragment (Frame.Application).
+func Validate(frame *Frame) []Violation {
+	var violations []Violation
+
+	report := func(rule RuleID, severity Severity, object int, format string, args ...any) {
+		violations = append(violations, Violation{
+			Rule:     rule,
+			Severity: severity,
+			Object:   object,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	validateDataLink(frame, report)
+
+	switch app := frame.Application.(type) {
+	case *ApplicationRequest:
+		validateRequest(app, frame.DataLink.Control.Direction, report)
+	case *ApplicationResponse:
+		validateResponse(app, frame.DataLink.Control.Direction, report)
+	}
+
+	return violations
+}
+
+// reporter records a Violation.
+type reporter func(rule RuleID, severity Severity, object int, format string, args ...any)
+
+func validateDataLink(frame *Frame, report reporter) {
+	control := frame.DataLink.Control
+	userData := false
+
+	switch code := control.FunctionCode.(type) {
+	case DataLinkPrimaryFunctionCode:
+		switch {
+		case !control.Primary:
+			report(RuleLinkFunctionCode, SeverityError, -1, "primary function code %s without PRM", code)
+		case !isValidPrimaryFunctionCode(code):
+			report(RuleLinkFunctionCode, SeverityError, -1, "unknown primary function code 0x%X", byte(code))
+		case !checkPrimaryFunctionCodeFCVValidity(code, control.FrameCountValid):
+			report(RuleLinkFunctionCode, SeverityError, -1, "FCV %t with %s", control.FrameCountValid, code)
+		}
+
+		userData = code == ConfirmedUserData || code == UnconfirmedUserData
+	case DataLinkSecondaryFunctionCode:
+		switch {
+		case control.Primary:
+			report(RuleLinkFunctionCode, SeverityError, -1, "secondary function code %s with PRM", code)
+		case !isValidSecondaryFunctionCode(code):
+			report(RuleLinkFunctionCode, SeverityError, -1, "unknown secondary function code 0x%X", byte(code))
+		}
+
+		if control.FrameCountBit {
+			report(RuleLinkReserved, SeverityWarning, -1, "reserved bit set in a secondary frame")
+		}
+	default:
+		report(RuleLinkFunctionCode, SeverityError, -1, "no link function code")
+	}
+
+	if !userData && (frame.Application != nil || len(frame.Segment) > 0) {
+		report(RuleLinkUserData, SeverityError, -1, "transport data in a frame without a user data function code")
+	}
+}
+
+func validateRequest(request *ApplicationRequest, fromMaster bool, report reporter) {
+	fc := request.FunctionCode
+
+	if !fromMaster {
+		report(RuleFunctionDirection, SeverityError, -1, "request %s sent by an outstation", fc)
+	}
+
+	rules, ok := requestRules[fc]
+	if !ok {
+		if ResponseFunctionCode(fc) >= Response && ResponseFunctionCode(fc) <= AuthenticationResponse {
+			report(RuleFunctionDirection, SeverityError, -1,
+				"response function code %s sent by a master", ResponseFunctionCode(fc))
+		} else {
+			report(RuleFunctionCode, SeverityError, -1, "unknown request function code 0x%02X", byte(fc))
+		}
+
+		return
+	}
+
+	control := request.Control
+	if !control.First || !control.Final {
+		report(RuleApplicationControl, SeverityError, -1, "requests are single fragments, FIR and FIN must be set")
+	}
+
+	if control.Unsolicited && fc != Confirm {
+		report(RuleApplicationControl, SeverityError, -1, "UNS set in a %s request", fc)
+	}
+
+	if control.Confirm {
+		report(RuleApplicationControl, SeverityWarning, -1, "CON set in a request")
+	}
+
+	for i, object := range request.Data.Objects {
+		if !validateObject(object, i, fc.String(), rules, report) {
+			continue
+		}
+
+		prefix := object.Header.PointPrefixCode
+		sized := prefix == Size1Octet || prefix == Size2Octet || prefix == Size4Octet
+
+		if fc.headersOnly() && len(object.Points) > 0 && !sized {
+			report(RuleObjectValues, SeverityError, i, "%s request with point values", fc)
+		}
+	}
+}
+
+func validateResponse(response *ApplicationResponse, fromMaster bool, report reporter) {
+	fc := response.FunctionCode
+
+	if fromMaster {
+		report(RuleFunctionDirection, SeverityError, -1, "response %s sent by a master", fc)
+	}
+
+	rules, ok := responseRules[fc]
+	if !ok {
+		if RequestFunctionCode(fc) <= AuthenticationRequestNoAck {
+			report(RuleFunctionDirection, SeverityError, -1,
+				"request function code %s sent by an outstation", RequestFunctionCode(fc))
+		} else {
+			report(RuleFunctionCode, SeverityError, -1, "unknown response function code 0x%02X", byte(fc))
+		}
+
+		return
+	}
+
+	control := response.Control
+	unsolicited := fc == UnsolicitedResponse
+
+	if control.Unsolicited != unsolicited {
+		report(RuleApplicationControl, SeverityError, -1, "UNS %t in a %s", control.Unsolicited, fc)
+	}
+
+	if unsolicited && (!control.First || !control.Final || !control.Confirm) {
+		report(RuleApplicationControl, SeverityError, -1,
+			"unsolicited responses are single fragments with FIR, FIN and CON set")
+	}
+
+	if response.InternalIndications.Reserved1 || response.InternalIndications.Reserved2 {
+		report(RuleIIN, SeverityError, -1, "reserved IIN 2.6 or 2.7 set")
+	}
+
+	for i, object := range response.Data.Objects {
+		validateObject(object, i, fc.String(), rules, report)
+	}
+}
+
+// validateObject checks an object against the rules of the function code
+// named function. It returns false if the object is too malformed for the
+// function code's own checks.
+func validateObject(object DataObject, i int, function string, rules functionRules, report reporter) bool {
+	header := object.Header
+	group, variation := header.Group, header.Variation
+	qualifier := byte(header.PointPrefixCode)<<4 | byte(header.RangeSpecCode)
+
+	if _, ok := objectTypes[groupVariation{group, variation}]; !ok {
+		report(RuleObjectUnknown, SeverityError, i, "unknown group %d variation %d", group, variation)
+
+		return false
+	}
+
+	variations, ok := rules.objects[group]
+	if !ok || (variations != nil && !slices.Contains(variations, variation)) {
+		report(RuleObjectFunction, SeverityError, i, "g%dv%d isn't allowed in a %s", group, variation, function)
+	}
+
+	_, reservedRange := reservedRangeSpecifiers[header.RangeSpecCode]
+
+	switch {
+	case header.Reserved:
+		report(RuleReserved, SeverityError, i, "qualifier reserved bit set")
+	case header.PointPrefixCode == Reserved || reservedRange:
+		report(RuleReserved, SeverityError, i, "reserved qualifier 0x%02X", qualifier)
+	case !slices.Contains(rules.qualifiers, qualifier):
+		report(RuleQualifier, SeverityError, i, "qualifier 0x%02X isn't allowed in a %s", qualifier, function)
+	}
+
+	for n, point := range object.Points {
+		if commandStatus(point) > 0b01111111 {
+			report(RuleReserved, SeverityError, i, "point %d: command status reserved bit set", n)
+
+			break
+		}
+	}
+
+	if rangeField, ok := header.RangeField.(*StartStopRangeField); ok && rangeField.Start > rangeField.Stop {
+		report(RuleRange, SeverityError, i, "range start %d exceeds stop %d", rangeField.Start, rangeField.Stop)
+	}
+
+	prefixed := header.PointPrefixCode == Index1Octet || header.PointPrefixCode == Index2Octet ||
+		header.PointPrefixCode == Index4Octet
+
+	// Events of a point may repeat, in time order, so only static objects
+	// need increasing indexes.
+	if prefixed && !eventGroups[group] {
+		indexes := object.Indexes()
+		for n := 1; n < len(indexes); n++ {
+			if indexes[n] <= indexes[n-1] {
+				report(RuleIndexOrder, SeverityWarning, i, "index %d follows index %d", indexes[n], indexes[n-1])
+
+				break
+			}
+		}
+	}
+
+	return true
+}
+
+// commandStatus returns the status of a CROB or analog output block, which
+// decoding keeps as received, and 0 for any other point.
+func commandStatus(point Point) CommandStatus {
+	switch point := point.(type) {
+	case *CROB:
+		return point.Status
+	case *AnalogOutputBlock:
+		return point.Status
+	default:
+		return CommandStatusSuccess
+	}
+}
+
+// functionRules are the objects and qualifiers a function code allows.
+type functionRules struct {
+	// objects maps the allowed groups to their allowed variations, nil
+	// allowing every variation.
+	objects    map[uint8][]uint8
+	qualifiers []byte
+}
+
+// eventGroups are the event groups, which report the changes of a point in
+// time order.
+var eventGroups = map[uint8]bool{
+	2: true, 4: true, 11: true, 13: true, 22: true, 23: true, 32: true, 33: true,
+	42: true, 43: true, 88: true, 111: true, 113: true,
+}
+
+// Qualifiers allowed with requests that only name points, with point values,
+// and with controls.
+var (
+	headerQualifiers  = []byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x17, 0x28, 0x39, 0x5B}
+	valueQualifiers   = []byte{0x00, 0x01, 0x02, 0x07, 0x08, 0x09, 0x17, 0x28, 0x39, 0x5B}
+	controlQualifiers = []byte{0x00, 0x01, 0x07, 0x08, 0x17, 0x28, 0x39, 0x5B}
+)
+
+var (
+	noObjects = functionRules{qualifiers: headerQualifiers}
+	readRules = functionRules{
+		objects: map[uint8][]uint8{
+			0: nil, 1: nil, 2: nil, 3: nil, 4: nil, 10: nil, 11: nil, 13: nil,
+			20: nil, 21: nil, 22: nil, 23: nil, 30: nil, 31: nil, 32: nil, 33: nil, 34: nil,
+			40: nil, 42: nil, 43: nil, 50: {1}, 60: nil, 70: nil, 80: {1},
+			85: nil, 86: nil, 87: nil, 88: nil, 110: nil, 111: nil, 113: nil,
+		},
+		qualifiers: headerQualifiers,
+	}
+	writeRules = functionRules{
+		objects: map[uint8][]uint8{
+			0: nil, 34: nil, 50: {1, 3, 4}, 70: nil, 80: {1}, 85: nil, 86: nil, 87: nil, 110: nil, 112: nil,
+		},
+		qualifiers: valueQualifiers,
+	}
+	controlRules = functionRules{
+		objects:    map[uint8][]uint8{12: nil, 41: nil, 87: nil},
+		qualifiers: controlQualifiers,
+	}
+	freezeRules = functionRules{
+		objects:    map[uint8][]uint8{20: nil, 30: nil, 60: nil},
+		qualifiers: headerQualifiers,
+	}
+	freezeAtTimeRules = functionRules{
+		objects:    map[uint8][]uint8{20: nil, 30: nil, 50: {2}, 60: nil},
+		qualifiers: valueQualifiers,
+	}
+	applicationRules = functionRules{
+		objects:    map[uint8][]uint8{90: {1}},
+		qualifiers: headerQualifiers,
+	}
+	enableUnsolicitedRules = functionRules{
+		objects:    map[uint8][]uint8{60: {2, 3, 4}},
+		qualifiers: headerQualifiers,
+	}
+	assignClassRules = functionRules{
+		objects: map[uint8][]uint8{
+			1: nil, 3: nil, 10: nil, 20: nil, 21: nil, 30: nil, 40: nil, 60: nil, 110: nil,
+		},
+		qualifiers: headerQualifiers,
+	}
+	fileRules = functionRules{
+		objects:    map[uint8][]uint8{70: nil},
+		qualifiers: valueQualifiers,
+	}
+	activateConfigRules = functionRules{
+		objects:    map[uint8][]uint8{70: {8}, 110: nil},
+		qualifiers: valueQualifiers,
+	}
+	authenticationRules = functionRules{
+		objects:    map[uint8][]uint8{120: nil},
+		qualifiers: valueQualifiers,
+	}
+)
+
+var requestRules = map[RequestFunctionCode]functionRules{
+	Confirm:                    noObjects,
+	Read:                       readRules,
+	Write:                      writeRules,
+	Select:                     controlRules,
+	Operate:                    controlRules,
+	DirOperate:                 controlRules,
+	DirOperateNoAck:            controlRules,
+	Freeze:                     freezeRules,
+	FreezeNoAck:                freezeRules,
+	FreezeClear:                freezeRules,
+	FreezeClearNoAck:           freezeRules,
+	FreezeAtTime:               freezeAtTimeRules,
+	FreezeAtTimeNoAck:          freezeAtTimeRules,
+	ColdRestart:                noObjects,
+	WarmRestart:                noObjects,
+	InitializedData:            noObjects,
+	InitializeApplication:      applicationRules,
+	StartApplication:           applicationRules,
+	StopApplication:            applicationRules,
+	SaveConfiguration:          noObjects,
+	EnableUnsolicited:          enableUnsolicitedRules,
+	DisableUnsolicited:         enableUnsolicitedRules,
+	AssignClass:                assignClassRules,
+	DelayMeasurement:           noObjects,
+	RecordCurrentTime:          noObjects,
+	OpenFile:                   fileRules,
+	CloseFile:                  fileRules,
+	DeleteFile:                 fileRules,
+	GetFileInformation:         fileRules,
+	AuthenticateFile:           fileRules,
+	AbortFile:                  fileRules,
+	ActivateConfig:             activateConfigRules,
+	AuthenticationRequest:      authenticationRules,
+	AuthenticationRequestNoAck: authenticationRules,
+}
+
+var responseRules = map[ResponseFunctionCode]functionRules{
+	Response: {
+		objects: map[uint8][]uint8{
+			0: nil, 1: nil, 2: nil, 3: nil, 4: nil, 10: nil, 11: nil, 12: nil, 13: nil,
+			20: nil, 21: nil, 22: nil, 23: nil, 30: nil, 31: nil, 32: nil, 33: nil, 34: nil,
+			40: nil, 41: nil, 42: nil, 43: nil, 50: nil, 51: nil, 52: nil, 70: nil, 80: nil,
+			85: nil, 86: nil, 87: nil, 88: nil, 110: nil, 111: nil, 112: nil, 113: nil, 120: nil,
+		},
+		qualifiers: valueQualifiers,
+	},
+	UnsolicitedResponse: {
+		objects: map[uint8][]uint8{
+			2: nil, 4: nil, 11: nil, 13: nil, 22: nil, 23: nil, 32: nil, 33: nil, 42: nil, 43: nil,
+			51: nil, 70: nil, 82: nil, 88: nil, 111: nil, 113: nil, 120: nil,
+		},
+		qualifiers: valueQualifiers,
+	},
+	AuthenticationResponse: {objects: map[uint8][]uint8{120: nil}, qualifiers: valueQualifiers},
+}
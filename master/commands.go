@@ -0,0 +1,139 @@
+package master
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nblair2/go-dnp3/v2/dnp3"
+)
+
+// Errors returned by the control requests.
+var (
+	ErrControlRejected = errors.New("control request rejected")
+	ErrEchoMismatch    = errors.New("control response does not echo the request")
+)
+
+// CommandError reports a control point that the outstation did not accept.
+type CommandError struct {
+	Index  int
+	Status dnp3.CommandStatus
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("control of point %d failed: (%d) %s", e.Index, e.Status, e.Status)
+}
+
+// SelectAndOperate runs a select-before-operate sequence for the control
+// objects (Group 12 CROBs, Group 41 analog output blocks): a Select, a check
+// that the outstation echoed every object with a Success status, then an
+// Operate with the same objects and the next sequence number. No other
+// request from this session is sent in between.
+func (s *Session) SelectAndOperate(ctx context.Context, objects ...dnp3.DataObject) error {
+	s.requestMu.Lock()
+	defer s.requestMu.Unlock()
+
+	err := s.control(ctx, dnp3.Select, objects)
+	if err != nil {
+		return fmt.Errorf("select: %w", err)
+	}
+
+	err = s.control(ctx, dnp3.Operate, objects)
+	if err != nil {
+		return fmt.Errorf("operate: %w", err)
+	}
+
+	return nil
+}
+
+// DirectOperate operates the control objects without a prior select and
+// checks the echoed statuses.
+func (s *Session) DirectOperate(ctx context.Context, objects ...dnp3.DataObject) error {
+	s.requestMu.Lock()
+	defer s.requestMu.Unlock()
+
+	return s.control(ctx, dnp3.DirOperate, objects)
+}
+
+// DirectOperateNoAck operates the control objects without a prior select.
+// The outstation sends no response, so nothing is checked.
+func (s *Session) DirectOperateNoAck(ctx context.Context, objects ...dnp3.DataObject) error {
+	s.requestMu.Lock()
+	defer s.requestMu.Unlock()
+
+	return s.control(ctx, dnp3.DirOperateNoAck, objects)
+}
+
+// control sends a control request and checks the response echoes it. The
+// caller holds requestMu.
+func (s *Session) control(ctx context.Context, fc dnp3.RequestFunctionCode, objects []dnp3.DataObject) error {
+	req := dnp3.NewApplicationRequest()
+	req.FunctionCode = fc
+	req.Data.Objects = objects
+
+	want, err := req.Data.SerializeTo()
+	if err != nil {
+		return fmt.Errorf("encoding control objects: %w", err)
+	}
+
+	responses, err := s.request(ctx, req)
+	if err != nil || expectsNoResponse(fc) {
+		return err
+	}
+
+	if len(responses) != 1 {
+		return fmt.Errorf("%w: expected 1 fragment, got %d", ErrEchoMismatch, len(responses))
+	}
+
+	return checkEcho(responses[0], want)
+}
+
+// checkEcho returns an error unless response echoes the control objects
+// encoded in want with every status Success.
+func checkEcho(response *dnp3.ApplicationResponse, want []byte) error {
+	iin := response.InternalIndications
+	if iin.BadFunction || iin.ObjectUnknown || iin.ParameterError {
+		return fmt.Errorf("%w: bad function %t, object unknown %t, parameter error %t",
+			ErrControlRejected, iin.BadFunction, iin.ObjectUnknown, iin.ParameterError)
+	}
+
+	for _, object := range response.Data.Objects {
+		for _, point := range object.Points {
+			status, ok := commandStatus(point)
+			if !ok || status == dnp3.CommandStatusSuccess {
+				continue
+			}
+
+			index, err := point.GetIndex()
+			if err != nil {
+				index = -1
+			}
+
+			return &CommandError{Index: index, Status: status}
+		}
+	}
+
+	got, err := response.Data.SerializeTo()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrEchoMismatch, err)
+	}
+
+	if !bytes.Equal(got, want) {
+		return ErrEchoMismatch
+	}
+
+	return nil
+}
+
+// commandStatus returns the status of a control point, if it has one.
+func commandStatus(point dnp3.Point) (dnp3.CommandStatus, bool) {
+	switch point := point.(type) {
+	case *dnp3.CROB:
+		return point.Status, true
+	case *dnp3.AnalogOutputBlock:
+		return point.Status, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,279 @@
+package master
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/nblair2/go-dnp3/v2/dnp3"
+)
+
+// DefaultFileBlockSize is used when FileClient.MaxBlockSize is zero. It keeps
+// each block, with its headers, inside a single application fragment.
+const DefaultFileBlockSize = 1024
+
+// ErrFileResponse is returned when the response to a file request can't be
+// used: it is rejected with an error IIN, or lacks the expected object.
+var ErrFileResponse = errors.New("unusable file transfer response")
+
+// FileError reports a file operation that the outstation answered with a
+// status other than Success.
+type FileError struct {
+	Operation dnp3.RequestFunctionCode
+	Status    dnp3.FileStatus
+	Text      string
+}
+
+func (e *FileError) Error() string {
+	if e.Text != "" {
+		return fmt.Sprintf("%s failed: (%d) %s: %s", e.Operation, e.Status, e.Status, e.Text)
+	}
+
+	return fmt.Sprintf("%s failed: (%d) %s", e.Operation, e.Status, e.Status)
+}
+
+// FileClient transfers files from an outstation with the Group 70 file
+// transfer objects, over a Session.
+//
+// Only outstations that answer each file request in its own response are
+// supported. An outstation may instead send a null response and report the
+// file command status or file transport later, as an event; the request then
+// fails with ErrFileResponse.
+type FileClient struct {
+	session *Session
+
+	// MaxBlockSize is the largest block asked for; the outstation may choose
+	// a smaller one. Zero uses DefaultFileBlockSize.
+	MaxBlockSize uint16
+
+	mu                sync.Mutex // guards the fields below
+	authenticationKey uint32
+	requestID         uint16
+}
+
+// NewFileClient returns a FileClient that sends its requests on session.
+func NewFileClient(session *Session) *FileClient {
+	return &FileClient{session: session}
+}
+
+// Authenticate exchanges a user name and password for the authentication key
+// sent with later OpenFile requests.
+func (c *FileClient) Authenticate(ctx context.Context, userName, password string) error {
+	response, err := c.request(ctx, dnp3.AuthenticateFile, &dnp3.FileAuthentication{
+		UserName: userName,
+		Password: password,
+	})
+	if err != nil {
+		return err
+	}
+
+	auth, ok := findFileObject[*dnp3.FileAuthentication](response)
+	if !ok {
+		return fmt.Errorf("%w: %s: no file authentication object", ErrFileResponse, dnp3.AuthenticateFile)
+	}
+
+	if auth.AuthenticationKey == 0 {
+		return &FileError{Operation: dnp3.AuthenticateFile, Status: dnp3.FileStatusPermissionDenied}
+	}
+
+	c.SetAuthenticationKey(auth.AuthenticationKey)
+
+	return nil
+}
+
+// AuthenticationKey returns the key sent with every OpenFile.
+func (c *FileClient) AuthenticationKey() uint32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.authenticationKey
+}
+
+// SetAuthenticationKey sets the key sent with every OpenFile, as
+// Authenticate does.
+func (c *FileClient) SetAuthenticationKey(key uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.authenticationKey = key
+}
+
+// Get streams the remote file name into w and returns the number of bytes
+// written. The file is opened for reading, read block by block until the
+// last block, then closed; if the transfer fails part way the file is
+// aborted instead.
+func (c *FileClient) Get(ctx context.Context, name string, w io.Writer) (int64, error) {
+	maxBlockSize := c.MaxBlockSize
+	if maxBlockSize == 0 {
+		maxBlockSize = DefaultFileBlockSize
+	}
+
+	opened, err := c.commandStatus(ctx, dnp3.OpenFile, &dnp3.FileCommand{
+		Name:              name,
+		AuthenticationKey: c.AuthenticationKey(),
+		Mode:              dnp3.FileModeRead,
+		MaxBlockSize:      maxBlockSize,
+		RequestID:         c.nextRequestID(),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	written, err := c.read(ctx, opened.Handle, w)
+	if err != nil {
+		// Best effort: the transfer has already failed.
+		_, _ = c.commandStatus(ctx, dnp3.AbortFile, &dnp3.FileCommandStatus{
+			Handle:    opened.Handle,
+			RequestID: c.nextRequestID(),
+		})
+
+		return written, err
+	}
+
+	_, err = c.commandStatus(ctx, dnp3.CloseFile, &dnp3.FileCommandStatus{
+		Handle:    opened.Handle,
+		RequestID: c.nextRequestID(),
+	})
+
+	return written, err
+}
+
+// read asks for the blocks of an open file in order and writes their data to
+// w until the last block.
+func (c *FileClient) read(ctx context.Context, handle uint32, w io.Writer) (int64, error) {
+	var written int64
+
+	for block := uint32(0); ; block++ {
+		response, err := c.request(ctx, dnp3.Read, &dnp3.FileTransport{Handle: handle, Block: block})
+		if err != nil {
+			return written, err
+		}
+
+		if status, ok := findFileObject[*dnp3.FileTransportStatus](response); ok {
+			return written, &FileError{Operation: dnp3.Read, Status: status.Status, Text: status.Text}
+		}
+
+		transport, ok := findFileObject[*dnp3.FileTransport](response)
+		if !ok || transport.Handle != handle || transport.Block != block {
+			return written, fmt.Errorf("%w: %s: expected block %d of handle 0x%08X",
+				ErrFileResponse, dnp3.Read, block, handle)
+		}
+
+		n, err := w.Write(transport.Data)
+		written += int64(n)
+
+		if err != nil {
+			return written, fmt.Errorf("writing block %d: %w", block, err)
+		}
+
+		if transport.Last {
+			return written, nil
+		}
+	}
+}
+
+// commandStatus sends a file request and returns the file command status
+// that answers it, or a FileError if its status isn't Success.
+func (c *FileClient) commandStatus(
+	ctx context.Context,
+	fc dnp3.RequestFunctionCode,
+	point dnp3.Point,
+) (*dnp3.FileCommandStatus, error) {
+	response, err := c.request(ctx, fc, point)
+	if err != nil {
+		return nil, err
+	}
+
+	status, ok := findFileObject[*dnp3.FileCommandStatus](response)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s: no file command status", ErrFileResponse, fc)
+	}
+
+	if status.Status != dnp3.FileStatusSuccess {
+		return nil, &FileError{Operation: fc, Status: status.Status, Text: status.Text}
+	}
+
+	return status, nil
+}
+
+// request sends a request holding one file object and returns the response,
+// rejecting it if it has an error IIN or is a null response.
+func (c *FileClient) request(
+	ctx context.Context,
+	fc dnp3.RequestFunctionCode,
+	point dnp3.Point,
+) (*dnp3.ApplicationResponse, error) {
+	variation, err := fileVariation(point)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fc, err)
+	}
+
+	req := dnp3.NewApplicationRequest()
+	req.FunctionCode = fc
+	req.Data.Objects = []dnp3.DataObject{dnp3.NewSizedObject(70, variation, point)}
+
+	responses, err := c.session.Request(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fc, err)
+	}
+
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("%w: %s: no response", ErrFileResponse, fc)
+	}
+
+	iin := responses[0].InternalIndications
+	if iin.BadFunction || iin.ObjectUnknown || iin.ParameterError {
+		return nil, fmt.Errorf("%w: %s: bad function %t, object unknown %t, parameter error %t",
+			ErrFileResponse, fc, iin.BadFunction, iin.ObjectUnknown, iin.ParameterError)
+	}
+
+	if len(responses[0].Data.Objects) == 0 {
+		return nil, fmt.Errorf("%w: %s: null response (answers reported later as events aren't supported)",
+			ErrFileResponse, fc)
+	}
+
+	return responses[0], nil
+}
+
+func (c *FileClient) nextRequestID() uint16 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requestID++
+
+	return c.requestID
+}
+
+// fileVariation returns the Group 70 variation of a file object sent by the
+// master.
+func fileVariation(point dnp3.Point) (uint8, error) {
+	switch point.(type) {
+	case *dnp3.FileAuthentication:
+		return 2, nil
+	case *dnp3.FileCommand:
+		return 3, nil
+	case *dnp3.FileCommandStatus:
+		return 4, nil
+	case *dnp3.FileTransport:
+		return 5, nil
+	default:
+		return 0, fmt.Errorf("unexpected file object %T", point)
+	}
+}
+
+// findFileObject returns the first point of type T in response.
+func findFileObject[T dnp3.Point](response *dnp3.ApplicationResponse) (T, bool) {
+	for _, object := range response.Data.Objects {
+		for _, point := range object.Points {
+			if found, ok := point.(T); ok {
+				return found, true
+			}
+		}
+	}
+
+	var zero T
+
+	return zero, false
+}
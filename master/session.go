@@ -0,0 +1,467 @@
+// Package master implements the master (client) side of a DNP3 association
+// on top of the dnp3 codec. A Session owns a connection to one outstation,
+// assigns application sequence numbers, reassembles and confirms multi-
+// fragment responses, and hands back the decoded ApplicationResponses.
+package master
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/nblair2/go-dnp3/v2/dnp3"
+)
+
+// DefaultResponseTimeout is used when Config.ResponseTimeout is zero.
+const DefaultResponseTimeout = 5 * time.Second
+
+// Sentinel errors returned by Session requests.
+var (
+	ErrClosed           = errors.New("session closed")
+	ErrResponseTimeout  = errors.New("timed out waiting for response")
+	ErrResponseOverflow = errors.New("response fragments dropped: too many arrived unread")
+)
+
+// Config holds the link addresses and timing for a Session.
+type Config struct {
+	// LocalAddress is the master's data link address (Source).
+	LocalAddress uint16
+	// RemoteAddress is the outstation's data link address (Destination).
+	// Frames from any other Source are ignored.
+	RemoteAddress uint16
+	// ResponseTimeout bounds the wait for each response fragment when the
+	// request context has no earlier deadline.
+	ResponseTimeout time.Duration
+	// Clock replaces time.Now as the master's time for time
+	// synchronisation.
+	Clock func() time.Time
+	// Unsolicited receives the unsolicited responses from the outstation,
+	// in order, on a goroutine of its own; it may issue requests on the
+	// session. Unsolicited responses are confirmed whether or not it is set.
+	Unsolicited func(*dnp3.ApplicationResponse)
+}
+
+// Session is a master's association with a single outstation over a
+// connection. Requests are issued one at a time, as DNP3 allows only one
+// outstanding request per association; concurrent callers are serialized.
+type Session struct {
+	conn   net.Conn
+	config Config
+
+	requestMu sync.Mutex // one outstanding request at a time
+	writeMu   sync.Mutex // frames from requests and confirms don't interleave
+
+	mu           sync.Mutex // guards the fields below
+	pending      chan *dnp3.ApplicationResponse
+	overflow     chan struct{} // signalled when a fragment for pending is dropped
+	sequence     uint8
+	transportSeq uint8
+	err          error
+
+	// unsolicited passes unsolicited responses to queueUnsolicited, and
+	// lastUnsolicited is the encoding of the last one passed. Both belong to
+	// the read loop.
+	unsolicited     chan *dnp3.ApplicationResponse
+	lastUnsolicited []byte
+
+	done chan struct{}
+}
+
+// Dial connects to an outstation at address and returns a Session for it.
+func Dial(ctx context.Context, network, address string, config Config) (*Session, error) {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, fmt.Errorf("dialing outstation: %w", err)
+	}
+
+	return NewSession(conn, config), nil
+}
+
+// NewSession returns a Session that owns conn and starts reading from it.
+// Close the Session to release the connection.
+func NewSession(conn net.Conn, config Config) *Session {
+	if config.ResponseTimeout <= 0 {
+		config.ResponseTimeout = DefaultResponseTimeout
+	}
+
+	session := &Session{
+		conn:   conn,
+		config: config,
+		done:   make(chan struct{}),
+	}
+
+	if config.Unsolicited != nil {
+		session.unsolicited = make(chan *dnp3.ApplicationResponse)
+		queued := make(chan *dnp3.ApplicationResponse)
+
+		go queueUnsolicited(session.unsolicited, queued)
+
+		go func() {
+			for response := range queued {
+				config.Unsolicited(response)
+			}
+		}()
+	}
+
+	go session.readLoop()
+
+	return session
+}
+
+// Close closes the connection. Requests in flight return ErrClosed.
+func (s *Session) Close() error {
+	err := s.conn.Close()
+	if err != nil && !errors.Is(err, net.ErrClosed) {
+		return fmt.Errorf("closing connection: %w", err)
+	}
+
+	<-s.done
+
+	return nil
+}
+
+// Err returns the error that stopped the session, or nil while it is open.
+func (s *Session) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+// Request sends req and returns the response fragments, in order, once the
+// fragment with FIN arrives. The application control and sequence of req are
+// set by the session. Fragments that ask for confirmation (CON) are confirmed
+// as they arrive. Function codes that take no response (the NoAck variants)
+// return as soon as req has been sent.
+func (s *Session) Request(
+	ctx context.Context,
+	req *dnp3.ApplicationRequest,
+) ([]*dnp3.ApplicationResponse, error) {
+	s.requestMu.Lock()
+	defer s.requestMu.Unlock()
+
+	return s.request(ctx, req)
+}
+
+// request is Request for callers that hold requestMu.
+func (s *Session) request(
+	ctx context.Context,
+	req *dnp3.ApplicationRequest,
+) ([]*dnp3.ApplicationResponse, error) {
+	responses := make(chan *dnp3.ApplicationResponse, 16)
+	overflow := make(chan struct{}, 1)
+
+	s.mu.Lock()
+	if s.err != nil {
+		s.mu.Unlock()
+
+		return nil, s.err
+	}
+
+	sequence := s.sequence
+	s.sequence = (s.sequence + 1) & 0b00001111
+	s.pending = responses
+	s.overflow = overflow
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.pending = nil
+		s.overflow = nil
+		s.mu.Unlock()
+	}()
+
+	req.Control = dnp3.ApplicationControl{First: true, Final: true}
+
+	err := req.SetSequence(sequence)
+	if err != nil {
+		return nil, fmt.Errorf("setting request sequence: %w", err)
+	}
+
+	err = s.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if expectsNoResponse(req.FunctionCode) {
+		return nil, nil
+	}
+
+	return s.awaitResponse(ctx, sequence, responses, overflow)
+}
+
+// Read sends a Read request for the given object headers.
+func (s *Session) Read(
+	ctx context.Context,
+	headers ...dnp3.ObjectHeader,
+) ([]*dnp3.ApplicationResponse, error) {
+	req := dnp3.NewApplicationRequest()
+	req.FunctionCode = dnp3.Read
+
+	for _, header := range headers {
+		req.Data.Objects = append(req.Data.Objects, dnp3.DataObject{Header: header})
+	}
+
+	return s.Request(ctx, req)
+}
+
+// ReadClass reads the event and/or static data of the given classes (0 for
+// static data, 1-3 for events) in the order given.
+func (s *Session) ReadClass(
+	ctx context.Context,
+	classes ...uint8,
+) ([]*dnp3.ApplicationResponse, error) {
+	req, err := dnp3.NewRequest(dnp3.Read).Class(classes...).Request()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Request(ctx, req)
+}
+
+// IntegrityPoll reads all event classes followed by static (class 0) data.
+func (s *Session) IntegrityPoll(ctx context.Context) ([]*dnp3.ApplicationResponse, error) {
+	return s.ReadClass(ctx, 1, 2, 3, 0)
+}
+
+// ClassHeader returns the Group 60 "all objects" header for a class (0-3).
+func ClassHeader(class uint8) (dnp3.ObjectHeader, error) {
+	if class > 3 {
+		return dnp3.ObjectHeader{}, fmt.Errorf("class must be 0-3, got %d", class)
+	}
+
+	return dnp3.ObjectHeader{
+		Group:         60,
+		Variation:     class + 1,
+		RangeSpecCode: dnp3.NoRangeField,
+		RangeField:    &dnp3.AllRangeField{},
+	}, nil
+}
+
+// awaitResponse collects response fragments for the request sent with
+// sequence until one has FIN set. It fails with ErrResponseOverflow if the
+// read loop had to drop a fragment because responses was full.
+func (s *Session) awaitResponse(
+	ctx context.Context,
+	sequence uint8,
+	responses <-chan *dnp3.ApplicationResponse,
+	overflow <-chan struct{},
+) ([]*dnp3.ApplicationResponse, error) {
+	var fragments []*dnp3.ApplicationResponse
+
+	timer := time.NewTimer(s.config.ResponseTimeout)
+	defer timer.Stop()
+
+	expected := sequence
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fragments, fmt.Errorf("waiting for response: %w", ctx.Err())
+		case <-timer.C:
+			return fragments, ErrResponseTimeout
+		case <-s.done:
+			return fragments, s.Err()
+		case <-overflow:
+			return fragments, ErrResponseOverflow
+		case response := <-responses:
+			// Stale or repeated fragments from an earlier exchange are dropped.
+			if response.Control.Sequence != expected || response.Control.First != (len(fragments) == 0) {
+				continue
+			}
+
+			if response.Control.Confirm {
+				err := s.confirm(ctx, response.Control.Sequence, false)
+				if err != nil {
+					return fragments, err
+				}
+			}
+
+			fragments = append(fragments, response)
+			if response.Control.Final {
+				return fragments, nil
+			}
+
+			expected = (expected + 1) & 0b00001111
+
+			timer.Reset(s.config.ResponseTimeout)
+		}
+	}
+}
+
+// confirm sends an application layer Confirm for a response fragment.
+func (s *Session) confirm(ctx context.Context, sequence uint8, unsolicited bool) error {
+	req := dnp3.NewApplicationRequest()
+	req.FunctionCode = dnp3.Confirm
+	req.Control = dnp3.ApplicationControl{
+		First:       true,
+		Final:       true,
+		Unsolicited: unsolicited,
+		Sequence:    sequence,
+	}
+
+	return s.send(ctx, req)
+}
+
+// send segments app into frames addressed to the outstation and writes them.
+func (s *Session) send(ctx context.Context, app dnp3.Application) error {
+	var dataLink dnp3.DataLink
+	dataLink.Source = s.config.LocalAddress
+	dataLink.Destination = s.config.RemoteAddress
+	dataLink.Control.Direction = true
+	dataLink.Control.Primary = true
+	dataLink.Control.FunctionCode = dnp3.UnconfirmedUserData
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.mu.Lock()
+	transportSeq := s.transportSeq
+	s.mu.Unlock()
+
+	frames, err := dnp3.SegmentApplication(dataLink, app, transportSeq)
+	if err != nil {
+		return fmt.Errorf("segmenting request: %w", err)
+	}
+
+	var wire []byte
+
+	for _, frame := range frames {
+		buf := gopacket.NewSerializeBuffer()
+
+		err = frame.SerializeTo(buf, gopacket.SerializeOptions{})
+		if err != nil {
+			return fmt.Errorf("encoding request frame: %w", err)
+		}
+
+		wire = append(wire, buf.Bytes()...)
+	}
+
+	s.mu.Lock()
+	//nolint:gosec // G115 - frame count is bounded by the fragment size
+	s.transportSeq = (transportSeq + uint8(len(frames))) & 0b00111111
+	s.mu.Unlock()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(s.config.ResponseTimeout)
+	}
+
+	err = s.conn.SetWriteDeadline(deadline)
+	if err != nil {
+		return fmt.Errorf("setting write deadline: %w", err)
+	}
+
+	_, err = s.conn.Write(wire)
+	if err != nil {
+		return fmt.Errorf("writing request: %w", err)
+	}
+
+	return nil
+}
+
+// readLoop decodes frames from the connection until it fails, dispatching
+// every reassembled response. Frames from stations other than the configured
+// outstation are dropped.
+func (s *Session) readLoop() {
+	defer close(s.done)
+
+	if s.unsolicited != nil {
+		defer close(s.unsolicited)
+	}
+
+	reassembler := dnp3.NewTransportReassembler()
+	buf := make([]byte, 4096)
+
+	var pending []byte
+
+	for {
+		n, err := s.conn.Read(buf)
+		if err != nil {
+			s.fail(err)
+
+			return
+		}
+
+		frames, remainder, err := dnp3.ParseFrames(append(pending, buf[:n]...))
+		// A malformed frame poisons the rest of the buffer; drop it and
+		// start over with the next read.
+		pending = remainder
+		if err != nil {
+			pending = nil
+		}
+
+		for _, frame := range frames {
+			if frame.DataLink.Source != s.config.RemoteAddress {
+				continue
+			}
+
+			app, err := reassembler.Push(frame)
+			if err != nil || app == nil {
+				continue
+			}
+
+			if response, ok := app.(*dnp3.ApplicationResponse); ok {
+				s.dispatch(response)
+			}
+		}
+	}
+}
+
+// dispatch routes a response to the request waiting for it. A response that
+// doesn't fit in its queue is dropped, and the request told through overflow.
+func (s *Session) dispatch(response *dnp3.ApplicationResponse) {
+	if response.Control.Unsolicited {
+		s.handleUnsolicited(response)
+
+		return
+	}
+
+	s.mu.Lock()
+	responses, overflow := s.pending, s.overflow
+	s.mu.Unlock()
+
+	if responses == nil {
+		return
+	}
+
+	select {
+	case responses <- response:
+	default:
+		select {
+		case overflow <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// fail records why the session stopped.
+func (s *Session) fail(err error) {
+	if errors.Is(err, net.ErrClosed) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrClosedPipe) {
+		err = ErrClosed
+	} else {
+		err = fmt.Errorf("%w: %w", ErrClosed, err)
+	}
+
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+}
+
+// expectsNoResponse reports whether the outstation never answers fc.
+func expectsNoResponse(fc dnp3.RequestFunctionCode) bool {
+	switch fc { //nolint:exhaustive // only the no-acknowledgement codes matter
+	case dnp3.Confirm, dnp3.DirOperateNoAck, dnp3.FreezeNoAck, dnp3.FreezeClearNoAck,
+		dnp3.FreezeAtTimeNoAck, dnp3.AuthenticationRequestNoAck:
+		return true
+	default:
+		return false
+	}
+}
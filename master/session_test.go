@@ -0,0 +1,686 @@
+package master_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/nblair2/go-dnp3/v2/dnp3"
+	"github.com/nblair2/go-dnp3/v2/master"
+)
+
+// fakeOutstation reads requests from one end of a net.Pipe and lets the test
+// script the responses.
+type fakeOutstation struct {
+	t           *testing.T
+	conn        net.Conn
+	reassembler *dnp3.TransportReassembler
+	pending     []byte
+	seq         uint8
+	source      uint16
+}
+
+func newFakeOutstation(t *testing.T, conn net.Conn) *fakeOutstation {
+	t.Helper()
+
+	return &fakeOutstation{t: t, conn: conn, reassembler: dnp3.NewTransportReassembler(), source: 10}
+}
+
+// next returns the next complete request.
+func (o *fakeOutstation) next() (*dnp3.ApplicationRequest, error) {
+	buf := make([]byte, 1024)
+
+	for {
+		frames, remainder, err := dnp3.ParseFrames(o.pending)
+		if err != nil {
+			return nil, err
+		}
+
+		o.pending = remainder
+
+		for _, frame := range frames {
+			app, err := o.reassembler.Push(frame)
+			if err != nil {
+				return nil, err
+			}
+
+			if req, ok := app.(*dnp3.ApplicationRequest); ok {
+				return req, nil
+			}
+		}
+
+		n, err := o.conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+
+		o.pending = append(o.pending, buf[:n]...)
+	}
+}
+
+// send writes a response fragment, segmenting it as needed.
+func (o *fakeOutstation) send(response *dnp3.ApplicationResponse) error {
+	var dataLink dnp3.DataLink
+	dataLink.Source = o.source
+	dataLink.Destination = 1
+	dataLink.Control.Primary = true
+	dataLink.Control.FunctionCode = dnp3.UnconfirmedUserData
+
+	frames, err := dnp3.SegmentApplication(dataLink, response, o.seq)
+	if err != nil {
+		return err
+	}
+
+	for _, frame := range frames {
+		buf := gopacket.NewSerializeBuffer()
+
+		err = frame.SerializeTo(buf, gopacket.SerializeOptions{})
+		if err != nil {
+			return err
+		}
+
+		_, err = o.conn.Write(buf.Bytes())
+		if err != nil {
+			return err
+		}
+
+		o.seq = (o.seq + 1) % 64
+	}
+
+	return nil
+}
+
+// analogFragment returns a response fragment with num g30v1 points.
+func analogFragment(control dnp3.ApplicationControl, num int) (*dnp3.ApplicationResponse, error) {
+	app := []byte{0x00, 0x81, 0x00, 0x00, 0x1e, 0x01, 0x01, 0x00, 0x00, byte(num - 1), byte((num - 1) >> 8)}
+	for i := range num {
+		app = append(app, 0x01, byte(i), 0x00, 0x00, 0x00)
+	}
+
+	response, err := dnp3.NewApplicationResponseFromBytes(app)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Control = control
+
+	return response, nil
+}
+
+// TestIntegrityPoll answers an integrity poll with a two-fragment response
+// (the first larger than one link frame) and checks sequencing and confirms.
+func TestIntegrityPoll(t *testing.T) {
+	t.Parallel()
+
+	masterConn, outstationConn := net.Pipe()
+	session := master.NewSession(masterConn, master.Config{LocalAddress: 1, RemoteAddress: 10})
+	outstation := newFakeOutstation(t, outstationConn)
+
+	errs := make(chan error, 1)
+
+	go func() {
+		errs <- func() error {
+			req, err := outstation.next()
+			if err != nil {
+				return err
+			}
+
+			if req.FunctionCode != dnp3.Read || len(req.Data.Objects) != 4 {
+				return errors.New("expected a read of four class headers")
+			}
+
+			seq := req.Control.Sequence
+
+			// A stale fragment from an earlier exchange must be ignored.
+			stale, err := analogFragment(dnp3.ApplicationControl{First: true, Final: true, Sequence: (seq + 9) % 16}, 1)
+			if err != nil {
+				return err
+			}
+
+			err = outstation.send(stale)
+			if err != nil {
+				return err
+			}
+
+			// So must one from another station, whatever its sequence.
+			outstation.source = 11
+
+			stray, err := analogFragment(dnp3.ApplicationControl{First: true, Final: true, Sequence: seq}, 1)
+			if err != nil {
+				return err
+			}
+
+			err = outstation.send(stray)
+			if err != nil {
+				return err
+			}
+
+			outstation.source = 10
+
+			for _, control := range []dnp3.ApplicationControl{
+				{First: true, Confirm: true, Sequence: seq},
+				{Final: true, Confirm: true, Sequence: (seq + 1) % 16},
+			} {
+				fragment, err := analogFragment(control, 80)
+				if err != nil {
+					return err
+				}
+
+				err = outstation.send(fragment)
+				if err != nil {
+					return err
+				}
+
+				confirm, err := outstation.next()
+				if err != nil {
+					return err
+				}
+
+				if confirm.FunctionCode != dnp3.Confirm || confirm.Control.Sequence != control.Sequence {
+					return errors.New("fragment was not confirmed with its sequence")
+				}
+			}
+
+			return nil
+		}()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	responses, err := session.IntegrityPoll(ctx)
+	if err != nil {
+		t.Fatal("IntegrityPoll:", err)
+	}
+
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 fragments, got %d", len(responses))
+	}
+
+	err = <-errs
+	if err != nil {
+		t.Fatal("outstation:", err)
+	}
+
+	if got := len(responses[0].Data.Objects[0].Points); got != 80 {
+		t.Fatalf("expected 80 points in the first fragment, got %d", got)
+	}
+
+	err = session.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = session.ReadClass(ctx, 0)
+	if !errors.Is(err, master.ErrClosed) {
+		t.Fatalf("expected ErrClosed after Close, got %v", err)
+	}
+}
+
+func TestRequest_timeout(t *testing.T) {
+	t.Parallel()
+
+	masterConn, outstationConn := net.Pipe()
+	session := master.NewSession(masterConn, master.Config{ResponseTimeout: 50 * time.Millisecond})
+
+	defer session.Close()
+
+	outstation := newFakeOutstation(t, outstationConn)
+
+	go func() { _, _ = outstation.next() }()
+
+	_, err := session.ReadClass(context.Background(), 0)
+	if !errors.Is(err, master.ErrResponseTimeout) {
+		t.Fatalf("expected ErrResponseTimeout, got %v", err)
+	}
+}
+
+// TestRequest_overflow sends more fragments than the master queues while it
+// is still confirming the first. The fragments dropped include FIN, so the
+// request must fail rather than wait for it.
+func TestRequest_overflow(t *testing.T) {
+	t.Parallel()
+
+	masterConn, outstationConn := net.Pipe()
+	session := master.NewSession(masterConn, master.Config{LocalAddress: 1, RemoteAddress: 10})
+
+	defer session.Close()
+
+	outstation := newFakeOutstation(t, outstationConn)
+
+	go func() {
+		req, err := outstation.next()
+		if err != nil {
+			return
+		}
+
+		const fragments = 20
+
+		for i := range uint8(fragments) {
+			fragment, err := analogFragment(dnp3.ApplicationControl{
+				First:    i == 0,
+				Final:    i == fragments-1,
+				Confirm:  true,
+				Sequence: (req.Control.Sequence + i) % 16,
+			}, 1)
+			if err != nil {
+				return
+			}
+
+			err = outstation.send(fragment)
+			if err != nil {
+				return
+			}
+		}
+
+		// Take the confirms until the session is closed.
+		for {
+			_, err = outstation.next()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.ReadClass(ctx, 0)
+	if !errors.Is(err, master.ErrResponseOverflow) {
+		t.Fatalf("expected ErrResponseOverflow, got %v", err)
+	}
+}
+
+// TestUnsolicited confirms every unsolicited response, including a repeat
+// the outstation sends when it misses a Confirm, and passes each one to the
+// callback once.
+func TestUnsolicited(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan *dnp3.ApplicationResponse, 4)
+	masterConn, outstationConn := net.Pipe()
+	session := master.NewSession(masterConn, master.Config{
+		LocalAddress:  1,
+		RemoteAddress: 10,
+		Unsolicited:   func(response *dnp3.ApplicationResponse) { received <- response },
+	})
+
+	defer session.Close()
+
+	outstation := newFakeOutstation(t, outstationConn)
+
+	for i, unsolicited := range []struct {
+		sequence uint8
+		points   int
+	}{{3, 1}, {3, 1}, {4, 2}} {
+		response, err := analogFragment(dnp3.ApplicationControl{
+			First:       true,
+			Final:       true,
+			Confirm:     true,
+			Unsolicited: true,
+			Sequence:    unsolicited.sequence,
+		}, unsolicited.points)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		response.FunctionCode = dnp3.UnsolicitedResponse
+
+		err = outstation.send(response)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		confirm, err := outstation.next()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if confirm.FunctionCode != dnp3.Confirm || !confirm.Control.Unsolicited ||
+			confirm.Control.Sequence != unsolicited.sequence {
+			t.Fatalf("response %d: expected an unsolicited Confirm for sequence %d:\n%s",
+				i, unsolicited.sequence, confirm.String())
+		}
+	}
+
+	for _, want := range []int{1, 2} {
+		select {
+		case response := <-received:
+			if points := len(response.Data.Objects[0].Points); points != want {
+				t.Fatalf("expected %d points, got %d", want, points)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("unsolicited response not passed to the callback")
+		}
+	}
+
+	select {
+	case <-received:
+		t.Fatal("repeated unsolicited response passed to the callback")
+	default:
+	}
+}
+
+// TestUnsolicited_flood sends a burst of unsolicited responses while the
+// callback for the first one issues a request. The read loop has to keep
+// reading the burst to reach the answer, whatever the callback is doing.
+func TestUnsolicited_flood(t *testing.T) {
+	t.Parallel()
+
+	const flood = 40
+
+	var (
+		session *master.Session
+		count   int
+	)
+
+	received := make(chan *dnp3.ApplicationResponse, flood)
+	requested := make(chan error, 1)
+	masterConn, outstationConn := net.Pipe()
+	session = master.NewSession(masterConn, master.Config{
+		LocalAddress:  1,
+		RemoteAddress: 10,
+		Unsolicited: func(response *dnp3.ApplicationResponse) {
+			count++
+			if count == 1 {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+
+				_, err := session.ReadClass(ctx, 0)
+				requested <- err
+			}
+
+			received <- response
+		},
+	})
+
+	defer session.Close()
+
+	outstation := newFakeOutstation(t, outstationConn)
+	errs := make(chan error, 1)
+
+	go func() {
+		for i := range uint8(flood) {
+			response, err := analogFragment(dnp3.ApplicationControl{
+				First:       true,
+				Final:       true,
+				Unsolicited: true,
+				Sequence:    i % 16,
+			}, 1)
+			if err != nil {
+				errs <- err
+
+				return
+			}
+
+			response.FunctionCode = dnp3.UnsolicitedResponse
+
+			err = outstation.send(response)
+			if err != nil {
+				errs <- err
+
+				return
+			}
+		}
+
+		req, err := outstation.next()
+		if err != nil {
+			errs <- err
+
+			return
+		}
+
+		response, err := analogFragment(dnp3.ApplicationControl{
+			First:    true,
+			Final:    true,
+			Sequence: req.Control.Sequence,
+		}, 1)
+		if err != nil {
+			errs <- err
+
+			return
+		}
+
+		errs <- outstation.send(response)
+	}()
+
+	select {
+	case err := <-requested:
+		if err != nil {
+			t.Fatal("request from the callback:", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("request from the callback stalled")
+	}
+
+	err := <-errs
+	if err != nil {
+		t.Fatal("outstation:", err)
+	}
+
+	for i := range flood {
+		select {
+		case <-received:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("only %d of %d unsolicited responses passed to the callback", i, flood)
+		}
+	}
+}
+
+// TestSyncTime answers a delay measurement with 10ms of processing time
+// while the master clock advances 100ms per reading, and checks the time
+// written includes the 45ms one-way delay.
+func TestSyncTime(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	readings := 0
+
+	masterConn, outstationConn := net.Pipe()
+	session := master.NewSession(masterConn, master.Config{
+		LocalAddress:  1,
+		RemoteAddress: 10,
+		Clock: func() time.Time {
+			readings++
+
+			return start.Add(time.Duration(readings-1) * 100 * time.Millisecond)
+		},
+	})
+
+	defer session.Close()
+
+	outstation := newFakeOutstation(t, outstationConn)
+	written := make(chan time.Time, 1)
+
+	go func() {
+		defer close(written)
+
+		for _, answer := range []func(*dnp3.ApplicationRequest, *dnp3.ApplicationResponse) error{
+			func(req *dnp3.ApplicationRequest, response *dnp3.ApplicationResponse) error {
+				if req.FunctionCode != dnp3.DelayMeasurement {
+					return fmt.Errorf("expected DelayMeasurement, got %s", req.FunctionCode)
+				}
+
+				points, err := dnp3.NewPoints(52, 2, dnp3.NoPrefix, 1)
+				if err != nil {
+					return err
+				}
+
+				err = points[0].SetRelTime(dnp3.RelativeTime(10 * time.Millisecond))
+				if err != nil {
+					return err
+				}
+
+				rangeField := dnp3.NewCountRangeField(1)
+				response.Data.Objects = []dnp3.DataObject{{
+					Header: dnp3.ObjectHeader{
+						Group: 52, Variation: 2, RangeSpecCode: rangeField.Code(), RangeField: rangeField,
+					},
+					Points: points,
+				}}
+
+				return nil
+			},
+			func(req *dnp3.ApplicationRequest, _ *dnp3.ApplicationResponse) error {
+				if req.FunctionCode != dnp3.Write || req.Data.Objects[0].Header.Group != 50 {
+					return fmt.Errorf("expected a Group 50 write, got %s", req.FunctionCode)
+				}
+
+				absTime, err := req.Data.Objects[0].Points[0].GetAbsTime()
+				written <- absTime.Time()
+
+				return err
+			},
+		} {
+			req, err := outstation.next()
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+
+			response := dnp3.NewApplicationResponse()
+			response.FunctionCode = dnp3.Response
+			response.Control = dnp3.ApplicationControl{First: true, Final: true, Sequence: req.Control.Sequence}
+
+			err = answer(req, response)
+			if err == nil {
+				err = outstation.send(response)
+			}
+
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+		}
+	}()
+
+	delay, err := session.SyncTime(context.Background())
+	if err != nil {
+		t.Fatal("SyncTime:", err)
+	}
+
+	if delay != 45*time.Millisecond {
+		t.Fatalf("expected a 45ms delay, got %s", delay)
+	}
+
+	if got, want := <-written, start.Add(245*time.Millisecond); !got.Equal(want) {
+		t.Fatalf("expected %s written, got %s", want, got)
+	}
+}
+
+// serveFile answers file requests for a single file, in blocks of blockSize,
+// until it is closed. Opening "later.log" gets a null response, as from an
+// outstation that reports the status later as an event, and opening any other
+// name fails with FileStatusNotFound.
+func serveFile(outstation *fakeOutstation, name string, content []byte, blockSize int) error {
+	const handle = 0x2A
+
+	for {
+		req, err := outstation.next()
+		if err != nil {
+			return err
+		}
+
+		response := dnp3.NewApplicationResponse()
+		response.FunctionCode = dnp3.Response
+		response.Control = dnp3.ApplicationControl{First: true, Final: true, Sequence: req.Control.Sequence}
+
+		switch point := req.Data.Objects[0].Points[0].(type) {
+		case *dnp3.FileCommand:
+			if point.Name == "later.log" {
+				break
+			}
+
+			status := &dnp3.FileCommandStatus{Handle: handle, RequestID: point.RequestID}
+
+			switch {
+			case point.Name != name:
+				status.Status = dnp3.FileStatusNotFound
+			case point.Mode != dnp3.FileModeRead:
+				status.Status = dnp3.FileStatusInvalidMode
+			default:
+				status.Size = uint32(len(content))
+				status.MaxBlockSize = uint16(blockSize)
+			}
+
+			response.Data.Objects = []dnp3.DataObject{dnp3.NewSizedObject(70, 4, status)}
+		case *dnp3.FileTransport:
+			start := min(int(point.Block)*blockSize, len(content))
+			end := min(start+blockSize, len(content))
+			response.Data.Objects = []dnp3.DataObject{dnp3.NewSizedObject(70, 5, &dnp3.FileTransport{
+				Handle: handle,
+				Block:  point.Block,
+				Last:   end == len(content),
+				Data:   content[start:end],
+			})}
+		case *dnp3.FileCommandStatus:
+			response.Data.Objects = []dnp3.DataObject{dnp3.NewSizedObject(70, 4, &dnp3.FileCommandStatus{
+				Handle:    handle,
+				RequestID: point.RequestID,
+			})}
+		default:
+			return fmt.Errorf("unexpected %s request with %T", req.FunctionCode, point)
+		}
+
+		err = outstation.send(response)
+		if err != nil || req.FunctionCode == dnp3.CloseFile {
+			return err
+		}
+	}
+}
+
+func TestFileClient_get(t *testing.T) {
+	t.Parallel()
+
+	masterConn, outstationConn := net.Pipe()
+	session := master.NewSession(masterConn, master.Config{LocalAddress: 1, RemoteAddress: 10})
+
+	defer session.Close()
+
+	content := make([]byte, 2500)
+	for i := range content {
+		content[i] = byte(i)
+	}
+
+	served := make(chan error, 1)
+
+	go func() { served <- serveFile(newFakeOutstation(t, outstationConn), "events.log", content, 1000) }()
+
+	client := master.NewFileClient(session)
+
+	var fileErr *master.FileError
+
+	_, err := client.Get(context.Background(), "missing.log", io.Discard)
+	if !errors.As(err, &fileErr) || fileErr.Status != dnp3.FileStatusNotFound {
+		t.Fatalf("expected a NotFound FileError, got %v", err)
+	}
+
+	_, err = client.Get(context.Background(), "later.log", io.Discard)
+	if !errors.Is(err, master.ErrFileResponse) {
+		t.Fatalf("expected ErrFileResponse for a null response, got %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	n, err := client.Get(context.Background(), "events.log", &buf)
+	if err != nil {
+		t.Fatal("Get:", err)
+	}
+
+	if n != int64(len(content)) || !bytes.Equal(buf.Bytes(), content) {
+		t.Fatalf("expected the %d-byte file, got %d bytes", len(content), n)
+	}
+
+	err = <-served
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,167 @@
+package master
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nblair2/go-dnp3/v2/dnp3"
+)
+
+// ErrTimeSync is returned when a time synchronisation step fails at the
+// outstation or its response can't be used.
+var ErrTimeSync = errors.New("time synchronisation failed")
+
+// SyncTime sets the outstation clock with the non-LAN procedure: a
+// DelayMeasurement request, whose Group 52 response gives the outstation's
+// processing time, then a write of Group 50 Var 1 with the master's time plus
+// the one-way propagation delay. It returns the delay. No other request from
+// this session is sent in between.
+func (s *Session) SyncTime(ctx context.Context) (time.Duration, error) {
+	s.requestMu.Lock()
+	defer s.requestMu.Unlock()
+
+	measure := dnp3.NewApplicationRequest()
+	measure.FunctionCode = dnp3.DelayMeasurement
+
+	sent := s.now()
+
+	responses, err := s.request(ctx, measure)
+	if err != nil {
+		return 0, fmt.Errorf("delay measurement: %w", err)
+	}
+
+	roundTrip := s.now().Sub(sent)
+
+	processing, err := processingTime(responses)
+	if err != nil {
+		return 0, err
+	}
+
+	delay := max((roundTrip-processing)/2, 0)
+
+	err = s.writeTime(ctx, 1, s.now().Add(delay))
+	if err != nil {
+		return 0, err
+	}
+
+	return delay, nil
+}
+
+// SyncTimeLAN sets the outstation clock with the LAN procedure: a
+// RecordCurrentTime request, at which the master and outstation both note
+// their time, then a write of Group 50 Var 3 with the master's noted time.
+func (s *Session) SyncTimeLAN(ctx context.Context) error {
+	s.requestMu.Lock()
+	defer s.requestMu.Unlock()
+
+	record := dnp3.NewApplicationRequest()
+	record.FunctionCode = dnp3.RecordCurrentTime
+
+	recorded := s.now()
+
+	responses, err := s.request(ctx, record)
+	if err != nil {
+		return fmt.Errorf("record current time: %w", err)
+	}
+
+	err = checkTimeResponse(responses)
+	if err != nil {
+		return fmt.Errorf("record current time: %w", err)
+	}
+
+	return s.writeTime(ctx, 3, recorded)
+}
+
+// writeTime writes at as a Group 50 object of variation.
+func (s *Session) writeTime(ctx context.Context, variation uint8, at time.Time) error {
+	points, err := dnp3.NewPoints(50, variation, dnp3.NoPrefix, 1)
+	if err != nil {
+		return fmt.Errorf("writing time: %w", err)
+	}
+
+	err = points[0].SetAbsTime(dnp3.AbsoluteTime(at))
+	if err != nil {
+		return fmt.Errorf("writing time: %w", err)
+	}
+
+	rangeField := dnp3.NewCountRangeField(1)
+	write := dnp3.NewApplicationRequest()
+	write.FunctionCode = dnp3.Write
+	write.Data.Objects = []dnp3.DataObject{{
+		Header: dnp3.ObjectHeader{
+			Group:         50,
+			Variation:     variation,
+			RangeSpecCode: rangeField.Code(),
+			RangeField:    rangeField,
+		},
+		Points: points,
+	}}
+
+	responses, err := s.request(ctx, write)
+	if err == nil {
+		err = checkTimeResponse(responses)
+	}
+
+	if err != nil {
+		return fmt.Errorf("writing time: %w", err)
+	}
+
+	return nil
+}
+
+// processingTime returns the outstation processing time reported by the
+// Group 52 object of a DelayMeasurement response.
+func processingTime(responses []*dnp3.ApplicationResponse) (time.Duration, error) {
+	err := checkTimeResponse(responses)
+	if err != nil {
+		return 0, fmt.Errorf("delay measurement: %w", err)
+	}
+
+	for _, object := range responses[0].Data.Objects {
+		if object.Header.Group != 52 || len(object.Points) != 1 {
+			continue
+		}
+
+		relTime, err := object.Points[0].GetRelTime()
+		if err != nil {
+			return 0, fmt.Errorf("%w: delay measurement: %w", ErrTimeSync, err)
+		}
+
+		// The codec reads both variations in milliseconds; the coarse
+		// variation counts seconds.
+		if object.Header.Variation == 1 {
+			return relTime.Duration() * 1000, nil
+		}
+
+		return relTime.Duration(), nil
+	}
+
+	return 0, fmt.Errorf("%w: delay measurement response has no time delay object", ErrTimeSync)
+}
+
+// checkTimeResponse reports an error IIN in the response to a time
+// synchronisation request.
+func checkTimeResponse(responses []*dnp3.ApplicationResponse) error {
+	if len(responses) == 0 {
+		return fmt.Errorf("%w: no response", ErrTimeSync)
+	}
+
+	iin := responses[0].InternalIndications
+	if iin.BadFunction || iin.ObjectUnknown || iin.ParameterError {
+		return fmt.Errorf("%w: bad function %t, object unknown %t, parameter error %t",
+			ErrTimeSync, iin.BadFunction, iin.ObjectUnknown, iin.ParameterError)
+	}
+
+	return nil
+}
+
+// now reads Config.Clock, or the system clock if it is nil.
+func (s *Session) now() time.Time {
+	if s.config.Clock != nil {
+		return s.config.Clock()
+	}
+
+	return time.Now()
+}
@@ -0,0 +1,119 @@
+package master
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nblair2/go-dnp3/v2/dnp3"
+)
+
+// ErrUnsolicitedRejected is returned when the outstation refuses an
+// EnableUnsolicited or DisableUnsolicited request.
+var ErrUnsolicitedRejected = errors.New("unsolicited request rejected")
+
+// EnableUnsolicited asks the outstation to report the events of the given
+// classes (1-3) in unsolicited responses, which are passed to
+// Config.Unsolicited.
+func (s *Session) EnableUnsolicited(ctx context.Context, classes ...uint8) error {
+	return s.setUnsolicited(ctx, dnp3.EnableUnsolicited, classes)
+}
+
+// DisableUnsolicited asks the outstation to stop reporting the events of the
+// given classes (1-3) in unsolicited responses.
+func (s *Session) DisableUnsolicited(ctx context.Context, classes ...uint8) error {
+	return s.setUnsolicited(ctx, dnp3.DisableUnsolicited, classes)
+}
+
+func (s *Session) setUnsolicited(ctx context.Context, fc dnp3.RequestFunctionCode, classes []uint8) error {
+	req := dnp3.NewApplicationRequest()
+	req.FunctionCode = fc
+
+	for _, class := range classes {
+		if class == 0 {
+			return fmt.Errorf("%s: class must be 1-3, got 0", fc)
+		}
+
+		header, err := ClassHeader(class)
+		if err != nil {
+			return fmt.Errorf("%s: %w", fc, err)
+		}
+
+		req.Data.Objects = append(req.Data.Objects, dnp3.DataObject{Header: header})
+	}
+
+	responses, err := s.Request(ctx, req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fc, err)
+	}
+
+	for _, response := range responses {
+		iin := response.InternalIndications
+		if iin.BadFunction || iin.ObjectUnknown || iin.ParameterError {
+			return fmt.Errorf("%w: %s: bad function %t, object unknown %t, parameter error %t",
+				ErrUnsolicitedRejected, fc, iin.BadFunction, iin.ObjectUnknown, iin.ParameterError)
+		}
+	}
+
+	return nil
+}
+
+// handleUnsolicited confirms an unsolicited response and passes it on to
+// Config.Unsolicited. A response that repeats the last one, because the
+// outstation didn't see the Confirm, is confirmed again but not passed on.
+// It is only called from the read loop.
+func (s *Session) handleUnsolicited(response *dnp3.ApplicationResponse) {
+	if response.Control.Confirm {
+		go func() { _ = s.confirm(context.Background(), response.Control.Sequence, true) }()
+	}
+
+	if s.unsolicited == nil {
+		return
+	}
+
+	encoded, err := response.SerializeTo()
+	if err != nil || bytes.Equal(encoded, s.lastUnsolicited) {
+		return
+	}
+
+	s.lastUnsolicited = encoded
+	s.unsolicited <- response
+}
+
+// queueUnsolicited passes the responses from in to out in order, holding any
+// that out isn't ready for. The read loop therefore never waits for
+// Config.Unsolicited, which may be waiting for the read loop to deliver the
+// response to a request of its own. out is closed once in is closed and the
+// queue is empty.
+func queueUnsolicited(in <-chan *dnp3.ApplicationResponse, out chan<- *dnp3.ApplicationResponse) {
+	defer close(out)
+
+	var queue []*dnp3.ApplicationResponse
+
+	for in != nil || len(queue) > 0 {
+		// Sending on a nil channel blocks, so with nothing queued the
+		// select only receives.
+		var (
+			send chan<- *dnp3.ApplicationResponse
+			next *dnp3.ApplicationResponse
+		)
+
+		if len(queue) > 0 {
+			send, next = out, queue[0]
+		}
+
+		select {
+		case response, ok := <-in:
+			if !ok {
+				in = nil
+
+				continue
+			}
+
+			queue = append(queue, response)
+		case send <- next:
+			queue = queue[1:]
+		}
+	}
+}
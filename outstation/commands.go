@@ -0,0 +1,156 @@
+package outstation
+
+import (
+	"bytes"
+	"math"
+	"time"
+
+	"github.com/nblair2/go-dnp3/v2/dnp3"
+)
+
+// DefaultSelectTimeout is used when Config.SelectTimeout is zero.
+const DefaultSelectTimeout = 5 * time.Second
+
+// CommandHandler executes controls for an Outstation. Each callback is called
+// once per control point: with operate false when the point is selected, and
+// with operate true when it is operated (after a valid select, or directly).
+// The returned status is echoed to the master. A nil callback answers
+// CommandStatusNotSupported.
+type CommandHandler struct {
+	// CROB handles Group 12 Var 1 controls.
+	CROB func(index uint16, crob dnp3.CROB, operate bool) dnp3.CommandStatus
+	// AnalogOutput handles Group 41 analog output blocks.
+	AnalogOutput func(index uint16, value float64, operate bool) dnp3.CommandStatus
+}
+
+// selection is the last accepted Select, waiting for its Operate.
+type selection struct {
+	sequence uint8
+	objects  []byte
+	at       time.Time
+}
+
+// control handles Select, Operate, DirOperate and DirOperateNoAck: every
+// control point is passed to the CommandHandler and the request objects are
+// echoed with the resulting statuses. An Operate is only executed if it
+// follows a successful Select with the next sequence number and identical
+// objects, within the select timeout; otherwise every point is answered
+// NoSelect or Timeout.
+func (o *Outstation) control(req *dnp3.ApplicationRequest, response *dnp3.ApplicationResponse) {
+	iin := &response.InternalIndications
+
+	encoded, err := req.Data.SerializeTo()
+	if err != nil {
+		iin.ParameterError = true
+
+		return
+	}
+
+	failure := dnp3.CommandStatusSuccess
+	if req.FunctionCode == dnp3.Operate {
+		failure = o.checkSelection(req.Control.Sequence, encoded)
+	}
+
+	operate := req.FunctionCode != dnp3.Select
+	accepted := true
+
+	for _, object := range req.Data.Objects {
+		if object.Header.Group != 12 && object.Header.Group != 41 {
+			iin.ObjectUnknown = true
+
+			continue
+		}
+
+		for _, point := range object.Points {
+			status := failure
+			if status == dnp3.CommandStatusSuccess {
+				status = o.execute(object.Header, point, operate)
+			}
+
+			if !setCommandStatus(point, status) {
+				continue // pattern mask bits carry no status
+			}
+
+			accepted = accepted && status == dnp3.CommandStatusSuccess
+		}
+
+		response.Data.Objects = append(response.Data.Objects, object)
+	}
+
+	if req.FunctionCode == dnp3.Select && accepted && !iin.ObjectUnknown {
+		o.mu.Lock()
+		o.selection = &selection{sequence: req.Control.Sequence, objects: encoded, at: time.Now()}
+		o.mu.Unlock()
+	}
+}
+
+// checkSelection consumes the pending selection and returns the status an
+// Operate with sequence and objects gets without reaching the handler, or
+// Success if it may go ahead.
+func (o *Outstation) checkSelection(sequence uint8, objects []byte) dnp3.CommandStatus {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	selected := o.selection
+	o.selection = nil
+
+	timeout := o.config.SelectTimeout
+	if timeout <= 0 {
+		timeout = DefaultSelectTimeout
+	}
+
+	switch {
+	case selected == nil,
+		sequence != (selected.sequence+1)&0b00001111,
+		!bytes.Equal(objects, selected.objects):
+		return dnp3.CommandStatusNoSelect
+	case time.Since(selected.at) > timeout:
+		return dnp3.CommandStatusTimeout
+	default:
+		return dnp3.CommandStatusSuccess
+	}
+}
+
+// execute passes one control point to the CommandHandler.
+func (o *Outstation) execute(header dnp3.ObjectHeader, point dnp3.Point, operate bool) dnp3.CommandStatus {
+	handler := o.config.Commands
+
+	index, err := point.GetIndex()
+	if err != nil || index < 0 || index > math.MaxUint16 {
+		return dnp3.CommandStatusFormatError
+	}
+
+	switch point := point.(type) {
+	case *dnp3.CROB:
+		if header.Variation != 1 || handler.CROB == nil {
+			return dnp3.CommandStatusNotSupported
+		}
+
+		return handler.CROB(uint16(index), *point, operate)
+	case *dnp3.AnalogOutputBlock:
+		if handler.AnalogOutput == nil {
+			return dnp3.CommandStatusNotSupported
+		}
+
+		return handler.AnalogOutput(uint16(index), point.Value, operate)
+	default:
+		return dnp3.CommandStatusNotSupported
+	}
+}
+
+// setCommandStatus writes status into a control point, reporting false for
+// points that have no status.
+func setCommandStatus(point dnp3.Point, status dnp3.CommandStatus) bool {
+	switch point := point.(type) {
+	case *dnp3.CROB:
+		point.Status = status
+
+		return true
+	case *dnp3.AnalogOutputBlock:
+		point.Status = status
+
+		return true
+	default:
+		return false
+	}
+}
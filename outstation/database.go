@@ -0,0 +1,189 @@
+package outstation
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/nblair2/go-dnp3/v2/dnp3"
+)
+
+// PointType is a kind of static point held in a Database. Each maps onto one
+// DNP3 static object group.
+//
+//go:generate stringer -type=PointType
+type PointType uint8
+
+const (
+	BinaryInput          PointType = iota // Group 1
+	DoubleBitBinaryInput                  // Group 3
+	BinaryOutputStatus                    // Group 10
+	Counter                               // Group 20
+	FrozenCounter                         // Group 21
+	AnalogInput                           // Group 30
+	AnalogOutputStatus                    // Group 40
+	numPointTypes
+)
+
+// Double-bit binary input states, as carried in Group 3 and 4.
+const (
+	DoubleBitIntermediate  = 0
+	DoubleBitOff           = 1
+	DoubleBitOn            = 2
+	DoubleBitIndeterminate = 3
+)
+
+// PointValue is the current state of one point. Binary points use 0 and 1,
+// double-bit binary inputs one of the DoubleBit states, and counters and
+// analogs their numeric value.
+type PointValue struct {
+	Value float64         `json:"value"`
+	Flags dnp3.PointFlags `json:"flags"`
+}
+
+// Database is the in-memory point database an Outstation serves. It is safe
+// for concurrent use, so points can be updated while masters are polling.
+//
+// Every point is reported in class 0 (static) data. A point assigned to event
+// class 1, 2 or 3 also produces an event each time Set changes its value or
+// flags.
+type Database struct {
+	mu        sync.RWMutex
+	points    [numPointTypes]map[uint16]record
+	observers []changeObserver
+}
+
+// record is a stored point.
+type record struct {
+	value PointValue
+	class uint8
+}
+
+// changeObserver is told about every change to a point assigned to an event
+// class, while the database is locked so changes are seen in order.
+type changeObserver func(kind PointType, index uint16, value PointValue, class uint8)
+
+// NewDatabase returns an empty Database.
+func NewDatabase() *Database {
+	db := &Database{}
+	for kind := range db.points {
+		db.points[kind] = make(map[uint16]record)
+	}
+
+	return db
+}
+
+// Set stores the value of a point, adding the point, with no event class, if
+// it does not exist.
+func (db *Database) Set(kind PointType, index uint16, value PointValue) error {
+	if kind >= numPointTypes {
+		return fmt.Errorf("unknown point type %d", kind)
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	existing, ok := db.points[kind][index]
+	db.points[kind][index] = record{value: value, class: existing.class}
+
+	if ok && existing.class != 0 && existing.value != value {
+		for _, observer := range db.observers {
+			observer(kind, index, value, existing.class)
+		}
+	}
+
+	return nil
+}
+
+// Get returns the value of a point and whether it exists.
+func (db *Database) Get(kind PointType, index uint16) (PointValue, bool) {
+	if kind >= numPointTypes {
+		return PointValue{}, false
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	point, ok := db.points[kind][index]
+
+	return point.value, ok
+}
+
+// SetClass assigns an existing point to an event class: 0 for none (static
+// data only) or 1-3.
+func (db *Database) SetClass(kind PointType, index uint16, class uint8) error {
+	if kind >= numPointTypes {
+		return fmt.Errorf("unknown point type %d", kind)
+	}
+
+	if class > 3 {
+		return fmt.Errorf("class must be 0-3, got %d", class)
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	point, ok := db.points[kind][index]
+	if !ok {
+		return fmt.Errorf("no %s point %d", kind, index)
+	}
+
+	point.class = class
+	db.points[kind][index] = point
+
+	return nil
+}
+
+// Class returns the event class of a point and whether it exists.
+func (db *Database) Class(kind PointType, index uint16) (uint8, bool) {
+	if kind >= numPointTypes {
+		return 0, false
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	point, ok := db.points[kind][index]
+
+	return point.class, ok
+}
+
+// observe registers observer for changes to points with an event class.
+func (db *Database) observe(observer changeObserver) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.observers = append(db.observers, observer)
+}
+
+// Indexes returns the indexes of every point of kind, in ascending order.
+func (db *Database) Indexes(kind PointType) []uint16 {
+	if kind >= numPointTypes {
+		return nil
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	indexes := make([]uint16, 0, len(db.points[kind]))
+	for index := range db.points[kind] {
+		indexes = append(indexes, index)
+	}
+
+	slices.Sort(indexes)
+
+	return indexes
+}
+
+// values returns the values of the given points of kind, which must exist.
+func (db *Database) values(kind PointType, indexes []uint16) []PointValue {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	values := make([]PointValue, len(indexes))
+	for i, index := range indexes {
+		values[i] = db.points[kind][index].value
+	}
+
+	return values
+}
@@ -0,0 +1,290 @@
+package outstation
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/nblair2/go-dnp3/v2/dnp3"
+)
+
+// DefaultEventBufferSize is the number of events buffered per class when
+// Config.EventBufferSize leaves the class at zero.
+const DefaultEventBufferSize = 100
+
+// event is a buffered change to a point.
+type event struct {
+	kind  PointType
+	index uint16
+	value PointValue
+	time  time.Time
+	class uint8
+	// sent marks events reported in a response that awaits confirmation.
+	sent bool
+	// unsolicited marks sent events reported in an unsolicited response.
+	unsolicited bool
+}
+
+// eventBuffer holds events until the master confirms the response that
+// reported them. It is guarded by the Outstation's mutex.
+type eventBuffer struct {
+	limits   [3]int
+	events   []*event
+	overflow bool
+}
+
+func newEventBuffer(limits [3]int) *eventBuffer {
+	for class, limit := range limits {
+		if limit <= 0 {
+			limits[class] = DefaultEventBufferSize
+		}
+	}
+
+	return &eventBuffer{limits: limits}
+}
+
+// add buffers e. When its class is full the oldest event of the class is
+// discarded and the buffer reports an overflow.
+func (b *eventBuffer) add(e *event) {
+	if b.count(e.class) >= b.limits[e.class-1] {
+		oldest := slices.IndexFunc(b.events, func(buffered *event) bool { return buffered.class == e.class })
+		b.events = slices.Delete(b.events, oldest, oldest+1)
+		b.overflow = true
+	}
+
+	b.events = append(b.events, e)
+}
+
+// count returns the number of buffered events of class.
+func (b *eventBuffer) count(class uint8) int {
+	count := 0
+
+	for _, e := range b.events {
+		if e.class == class {
+			count++
+		}
+	}
+
+	return count
+}
+
+// unsent reports whether class has events that have not been reported yet.
+func (b *eventBuffer) unsent(class uint8) bool {
+	return slices.ContainsFunc(b.events, func(e *event) bool { return e.class == class && !e.sent })
+}
+
+// selectEvents marks and returns, oldest first, up to limit unsent events
+// that match, as reported in a solicited or unsolicited response. A negative
+// limit selects all of them.
+func (b *eventBuffer) selectEvents(match func(*event) bool, limit int, unsolicited bool) []*event {
+	var selected []*event
+
+	for _, e := range b.events {
+		if limit >= 0 && len(selected) >= limit {
+			break
+		}
+
+		if !e.sent && match(e) {
+			e.sent = true
+			e.unsolicited = unsolicited
+			selected = append(selected, e)
+		}
+	}
+
+	return selected
+}
+
+// release discards the events reported in a confirmed solicited or
+// unsolicited response. The overflow is cleared once no class is full.
+func (b *eventBuffer) release(unsolicited bool) {
+	b.events = slices.DeleteFunc(b.events, func(e *event) bool { return e.sent && e.unsolicited == unsolicited })
+
+	full := false
+
+	for class, limit := range b.limits {
+		full = full || b.count(uint8(class+1)) >= limit //nolint:gosec // G115 - class is 0-2
+	}
+
+	b.overflow = b.overflow && full
+}
+
+// unselect returns events reported in an unconfirmed solicited response to
+// the buffer, so they are reported again. Events in an unsolicited response
+// stay with it until it is confirmed.
+func (b *eventBuffer) unselect() {
+	for _, e := range b.events {
+		e.sent = e.sent && e.unsolicited
+	}
+}
+
+// awaitingConfirm reports whether a solicited response reported events.
+func (b *eventBuffer) awaitingConfirm() bool {
+	return slices.ContainsFunc(b.events, func(e *event) bool { return e.sent && !e.unsolicited })
+}
+
+// recordEvent buffers a change to a point assigned to an event class.
+func (o *Outstation) recordEvent(kind PointType, index uint16, value PointValue, class uint8) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.events.add(&event{
+		kind:  kind,
+		index: index,
+		value: value,
+		time:  time.Now().Add(o.clockOffset),
+		class: class,
+	})
+
+	if o.config.Unsolicited {
+		o.notifyUnsolicited()
+	}
+}
+
+// readEvents returns the buffered events selected by an event group header.
+func (o *Outstation) readEvents(object dnp3.DataObject) ([]dnp3.DataObject, error) {
+	header := object.Header
+	kind, _ := pointTypeOfEvent(header.Group)
+
+	variation := header.Variation
+	if variation == 0 {
+		variation = pointTypes[kind].defaultEventVariation
+	} else if !slices.Contains(pointTypes[kind].eventVariations, variation) {
+		return nil, fmt.Errorf("%w: group %d variation %d", errObjectUnknown, header.Group, variation)
+	}
+
+	return o.eventObjects(object, func(e *event) (uint8, bool) {
+		return variation, e.kind == kind
+	}, false)
+}
+
+// eventObjects selects the unsent events that match, up to the count of a
+// limited-quantity header, and encodes them in the variation match returns.
+// Consecutive events of the same group and variation share an object with
+// index prefixes. The events are marked as reported in a solicited or
+// unsolicited response.
+func (o *Outstation) eventObjects(
+	object dnp3.DataObject,
+	match func(*event) (variation uint8, ok bool),
+	unsolicited bool,
+) ([]dnp3.DataObject, error) {
+	limit := -1
+
+	switch rangeField := object.Header.RangeField.(type) {
+	case *dnp3.AllRangeField:
+	case *dnp3.CountRangeField:
+		if object.Header.PointPrefixCode != dnp3.NoPrefix {
+			return nil, fmt.Errorf("%w: event reads can't select indexes", errParameter)
+		}
+
+		limit = min(int(rangeField.Count), math.MaxInt32)
+	default:
+		return nil, fmt.Errorf("%w: event reads need an all or count range", errParameter)
+	}
+
+	o.mu.Lock()
+	selected := o.events.selectEvents(func(e *event) bool {
+		_, ok := match(e)
+
+		return ok
+	}, limit, unsolicited)
+	o.mu.Unlock()
+
+	var objects []dnp3.DataObject
+
+	for start := 0; start < len(selected); {
+		kind := selected[start].kind
+		variation, _ := match(selected[start])
+
+		end := start + 1
+		for end < len(selected) && selected[end].kind == kind {
+			end++
+		}
+
+		events := selected[start:end]
+
+		prefixCode := dnp3.Index1Octet
+		if slices.ContainsFunc(events, func(e *event) bool { return e.index > math.MaxUint8 }) {
+			prefixCode = dnp3.Index2Octet
+		}
+
+		points, err := dnp3.NewPoints(pointTypes[kind].eventGroup, variation, prefixCode, len(events))
+		if err != nil {
+			return objects, err
+		}
+
+		for i, point := range points {
+			err = encodePoint(point, kind, events[i].value, events[i].time)
+			if err == nil {
+				err = point.SetIndex(int(events[i].index))
+			}
+
+			if err != nil {
+				return objects, fmt.Errorf("encoding %s event %d: %w", kind, events[i].index, err)
+			}
+		}
+
+		rangeField := dnp3.NewCountRangeField(uint32(len(events))) //nolint:gosec // G115 - bounded by the buffer limits
+		objects = append(objects, dnp3.DataObject{
+			Header: dnp3.ObjectHeader{
+				Group:           pointTypes[kind].eventGroup,
+				Variation:       variation,
+				PointPrefixCode: prefixCode,
+				RangeSpecCode:   rangeField.Code(),
+				RangeField:      rangeField,
+			},
+			Points: points,
+		})
+
+		start = end
+	}
+
+	return objects, nil
+}
+
+// assignClass applies an AssignClass request: each Group 60 header sets the
+// class given to the points of the static headers that follow it.
+func (o *Outstation) assignClass(req *dnp3.ApplicationRequest, iin *dnp3.ApplicationInternalIndications) {
+	class := -1
+
+	for _, object := range req.Data.Objects {
+		header := object.Header
+
+		if header.Group == 60 {
+			if header.Variation < 1 || header.Variation > 4 {
+				iin.ObjectUnknown = true
+
+				return
+			}
+
+			class = int(header.Variation) - 1
+
+			continue
+		}
+
+		kind, ok := pointTypeOf(header.Group)
+		if !ok {
+			iin.ObjectUnknown = true
+
+			continue
+		}
+
+		if class < 0 {
+			iin.ParameterError = true
+
+			return
+		}
+
+		indexes, complete := selectIndexes(o.database.Indexes(kind), object)
+		if !complete {
+			iin.ParameterError = true
+		}
+
+		for _, index := range indexes {
+			err := o.database.SetClass(kind, index, uint8(class)) //nolint:gosec // G115 - class is 0-3
+			if err != nil {
+				iin.ParameterError = true
+			}
+		}
+	}
+}
@@ -0,0 +1,388 @@
+// Package outstation implements the outstation (server) side of a DNP3
+// association on top of the dnp3 codec. An Outstation serves the points of a
+// Database to any number of masters over TCP, answering Read requests for
+// class 0-3 data and for specific group/variation ranges, and reports its
+// state in the internal indications (IIN) of every response.
+package outstation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/nblair2/go-dnp3/v2/dnp3"
+)
+
+// Config holds the link address, initial state, event buffer limits, control
+// handling and unsolicited reporting of an Outstation.
+type Config struct {
+	// LocalAddress is the outstation's data link address. Frames addressed to
+	// anything else except the broadcast addresses are ignored.
+	LocalAddress uint16
+	// NeedTime sets IIN1.4 (NeedTime) until a master writes the time.
+	NeedTime bool
+	// EventBufferSize limits the events buffered for classes 1, 2 and 3.
+	// Zero uses DefaultEventBufferSize.
+	EventBufferSize [3]int
+	// Commands executes Select, Operate and direct operate controls.
+	Commands CommandHandler
+	// SelectTimeout bounds the time between a Select and its Operate.
+	// Zero uses DefaultSelectTimeout.
+	SelectTimeout time.Duration
+	// Unsolicited enables unsolicited responses, sent to MasterAddress: a
+	// null unsolicited response after the restart, then the events of the
+	// classes a master enables with EnableUnsolicited. Without it,
+	// EnableUnsolicited and DisableUnsolicited are answered BadFunction.
+	Unsolicited   bool
+	MasterAddress uint16
+	// UnsolicitedConfirmTimeout is how long an unsolicited response waits
+	// for its Confirm before it is sent again. Zero uses
+	// DefaultUnsolicitedConfirmTimeout.
+	UnsolicitedConfirmTimeout time.Duration
+	// MaxFragmentSize is the largest response fragment, in bytes. Larger
+	// responses are split into fragments sent one at a time, each after the
+	// master confirms the one before. Zero uses dnp3.DefaultMaxFragmentSize.
+	MaxFragmentSize int
+	// ConfirmTimeout is how long the outstation waits for the Confirm of a
+	// fragment before dropping the rest of the response. Zero uses
+	// DefaultConfirmTimeout.
+	ConfirmTimeout time.Duration
+}
+
+// Outstation answers master requests from a Database. The Restart IIN is set
+// when the Outstation is created and stays set until a master clears it by
+// writing Group 80 Var 1 index 7.
+//
+// Changes to points assigned to an event class are buffered as events. A
+// response that reports events asks for confirmation, and the events are only
+// discarded once the master confirms it; otherwise the next read reports them
+// again. With Config.Unsolicited, events of the enabled classes are also
+// pushed to the master in unsolicited responses, which are repeated until
+// confirmed.
+type Outstation struct {
+	config   Config
+	database *Database
+	// unsolicitedNotify wakes the sessions to check for a due unsolicited
+	// response.
+	unsolicitedNotify chan struct{}
+
+	mu              sync.Mutex // guards the fields below
+	restart         bool
+	needTime        bool
+	allStations     bool
+	clockOffset     time.Duration
+	recordedTime    time.Time
+	events          *eventBuffer
+	awaitingConfirm bool
+	confirmSequence uint8
+	selection       *selection
+	// Unsolicited reporting state.
+	unsolicitedNull     bool
+	unsolicitedEnabled  [3]bool
+	unsolicitedSequence uint8
+	unsolicitedPending  *unsolicitedResponse
+}
+
+// New returns an Outstation serving database.
+func New(config Config, database *Database) *Outstation {
+	o := &Outstation{
+		config:            config,
+		database:          database,
+		unsolicitedNotify: make(chan struct{}, 1),
+		restart:           true,
+		needTime:          config.NeedTime,
+		events:            newEventBuffer(config.EventBufferSize),
+		unsolicitedNull:   config.Unsolicited,
+	}
+
+	database.observe(o.recordEvent)
+
+	return o
+}
+
+// Database returns the point database the Outstation serves.
+func (o *Outstation) Database() *Database {
+	return o.database
+}
+
+// Now returns the outstation's clock: the local time, adjusted by the last
+// time a master wrote.
+func (o *Outstation) Now() time.Time {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return time.Now().Add(o.clockOffset)
+}
+
+// ListenAndServe listens on the TCP address and serves masters until ctx is
+// canceled.
+func (o *Outstation) ListenAndServe(ctx context.Context, address string) error {
+	var config net.ListenConfig
+
+	listener, err := config.Listen(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", address, err)
+	}
+
+	return o.Serve(ctx, listener)
+}
+
+// Serve accepts connections on listener and serves each in its own goroutine
+// until ctx is canceled, at which point the listener and every connection are
+// closed and Serve returns nil.
+func (o *Outstation) Serve(ctx context.Context, listener net.Listener) error {
+	stop := context.AfterFunc(ctx, func() { _ = listener.Close() })
+	defer stop()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return fmt.Errorf("accepting connection: %w", err)
+		}
+
+		wg.Go(func() { _ = o.ServeConn(ctx, conn) })
+	}
+}
+
+// ServeConn serves the master on conn until the connection fails or ctx is
+// canceled, and closes conn before returning.
+func (o *Outstation) ServeConn(ctx context.Context, conn net.Conn) error {
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	defer conn.Close()
+
+	err := newSession(o, conn).run()
+	if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+
+	return err
+}
+
+// handle processes one request and returns the fragments of the response for
+// it, or nil when none is due (confirms, the NoAck function codes and
+// broadcasts).
+func (o *Outstation) handle(req *dnp3.ApplicationRequest, broadcast bool) []*dnp3.ApplicationResponse {
+	received := time.Now()
+
+	response := dnp3.NewApplicationResponse()
+	response.FunctionCode = dnp3.Response
+	response.Control = dnp3.ApplicationControl{
+		First:    true,
+		Final:    true,
+		Sequence: req.Control.Sequence,
+	}
+
+	iin := &response.InternalIndications
+
+	if req.FunctionCode == dnp3.Confirm {
+		o.confirm(req.Control)
+
+		return nil
+	}
+
+	// Any new request means the last response with events went unconfirmed,
+	// and anything but the Operate cancels a Select.
+	o.mu.Lock()
+	o.events.unselect()
+	o.awaitingConfirm = false
+
+	if req.FunctionCode != dnp3.Operate {
+		o.selection = nil
+	}
+
+	o.mu.Unlock()
+
+	switch req.FunctionCode { //nolint:exhaustive // everything else is BadFunction
+	case dnp3.Read:
+		o.read(req, response)
+	case dnp3.Write:
+		o.write(req, iin)
+	case dnp3.AssignClass:
+		o.assignClass(req, iin)
+	case dnp3.Select, dnp3.Operate, dnp3.DirOperate, dnp3.DirOperateNoAck:
+		o.control(req, response)
+	case dnp3.EnableUnsolicited, dnp3.DisableUnsolicited:
+		o.setUnsolicited(req, iin)
+	case dnp3.DelayMeasurement:
+		o.delayMeasurement(response, received)
+	case dnp3.RecordCurrentTime:
+		o.recordCurrentTime(received)
+	default:
+		iin.BadFunction = true
+	}
+
+	if broadcast {
+		o.mu.Lock()
+		o.allStations = true
+		o.mu.Unlock()
+
+		return nil
+	}
+
+	if noResponse(req.FunctionCode) {
+		return nil
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.indications(iin)
+
+	return o.fragments(response)
+}
+
+// fragments splits response into fragments of at most Config.MaxFragmentSize
+// bytes. If it reports events, the last fragment asks for confirmation and
+// the events are released when it comes. A response that can't be split
+// (an object larger than a fragment) is sent empty, with DeviceTrouble set.
+// o.mu must be held.
+func (o *Outstation) fragments(response *dnp3.ApplicationResponse) []*dnp3.ApplicationResponse {
+	size := o.config.MaxFragmentSize
+	if size <= 0 {
+		size = dnp3.DefaultMaxFragmentSize
+	}
+
+	builder := dnp3.NewResponse(dnp3.Response).Sequence(response.Control.Sequence).
+		IIN(response.InternalIndications).MaxFragmentSize(size)
+	for _, object := range response.Data.Objects {
+		builder.Object(object)
+	}
+
+	if o.events.awaitingConfirm() {
+		builder.Confirm()
+	}
+
+	fragments, err := builder.Fragments()
+	if err != nil {
+		o.events.unselect()
+
+		response.Data.Objects = nil
+		response.InternalIndications.DeviceTrouble = true
+
+		return []*dnp3.ApplicationResponse{response}
+	}
+
+	if o.events.awaitingConfirm() {
+		o.awaitingConfirm = true
+		o.confirmSequence = fragments[len(fragments)-1].Control.Sequence
+	}
+
+	return fragments
+}
+
+// confirm releases the events of the response a Confirm is for.
+func (o *Outstation) confirm(control dnp3.ApplicationControl) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if control.Unsolicited {
+		o.confirmUnsolicited(control.Sequence)
+
+		return
+	}
+
+	if !o.awaitingConfirm || control.Sequence != o.confirmSequence {
+		return
+	}
+
+	o.events.release(false)
+	o.awaitingConfirm = false
+}
+
+// indications sets the IIN bits that reflect outstation state. The caller
+// holds o.mu.
+func (o *Outstation) indications(iin *dnp3.ApplicationInternalIndications) {
+	iin.Restart = o.restart
+	iin.NeedTime = o.needTime
+	iin.AllStations = o.allStations
+	iin.Class1Events = o.events.unsent(1)
+	iin.Class2Events = o.events.unsent(2)
+	iin.Class3Events = o.events.unsent(3)
+	iin.BufferOverflow = o.events.overflow
+	o.allStations = false
+}
+
+// write applies the objects of a Write request.
+func (o *Outstation) write(req *dnp3.ApplicationRequest, iin *dnp3.ApplicationInternalIndications) {
+	for _, object := range req.Data.Objects {
+		switch {
+		case object.Header.Group == 80 && object.Header.Variation == 1:
+			if !o.clearRestart(object) {
+				iin.ParameterError = true
+			}
+		case object.Header.Group == 50 && object.Header.Variation == 1:
+			if !o.setTime(object) {
+				iin.ParameterError = true
+			}
+		case object.Header.Group == 50 && object.Header.Variation == 3:
+			if !o.setRecordedTime(object) {
+				iin.ParameterError = true
+			}
+		default:
+			iin.ObjectUnknown = true
+		}
+	}
+}
+
+// clearRestart handles a write of Group 80 Var 1. Only clearing the Restart
+// bit (index 7) is allowed.
+func (o *Outstation) clearRestart(object dnp3.DataObject) bool {
+	rangeField, ok := object.Header.RangeField.(*dnp3.StartStopRangeField)
+	if !ok || rangeField.Start != 7 || rangeField.Stop != 7 || len(object.Points) != 1 {
+		return false
+	}
+
+	value, ok := object.Points[0].GetValue().([]byte)
+	if !ok || len(value) != 1 || value[0]&0b00000001 != 0 {
+		return false
+	}
+
+	o.mu.Lock()
+	o.restart = false
+	o.mu.Unlock()
+
+	return true
+}
+
+// setTime handles a write of Group 50 Var 1, setting the outstation clock.
+func (o *Outstation) setTime(object dnp3.DataObject) bool {
+	if len(object.Points) != 1 {
+		return false
+	}
+
+	absTime, err := object.Points[0].GetAbsTime()
+	if err != nil {
+		return false
+	}
+
+	o.mu.Lock()
+	o.clockOffset = time.Until(absTime.Time())
+	o.needTime = false
+	o.mu.Unlock()
+
+	return true
+}
+
+// noResponse reports whether a request with function code fc is never
+// answered.
+func noResponse(fc dnp3.RequestFunctionCode) bool {
+	switch fc { //nolint:exhaustive // only the no-acknowledgement codes matter
+	case dnp3.Confirm, dnp3.DirOperateNoAck, dnp3.FreezeNoAck, dnp3.FreezeClearNoAck,
+		dnp3.FreezeAtTimeNoAck, dnp3.AuthenticationRequestNoAck:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,839 @@
+package outstation_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/nblair2/go-dnp3/v2/dnp3"
+	"github.com/nblair2/go-dnp3/v2/master"
+	"github.com/nblair2/go-dnp3/v2/outstation"
+)
+
+// newDatabase returns a database with two binary inputs and analog inputs
+// 0-2 and 5.
+func newDatabase(t *testing.T) *outstation.Database {
+	t.Helper()
+
+	online := dnp3.PointFlags{Online: true}
+	db := outstation.NewDatabase()
+
+	for _, point := range []struct {
+		kind  outstation.PointType
+		index uint16
+		value float64
+	}{
+		{outstation.BinaryInput, 0, 1},
+		{outstation.BinaryInput, 1, 0},
+		{outstation.AnalogInput, 0, 10},
+		{outstation.AnalogInput, 1, -20},
+		{outstation.AnalogInput, 2, 1e12},
+		{outstation.AnalogInput, 5, 2.5},
+	} {
+		err := db.Set(point.kind, point.index, outstation.PointValue{Value: point.value, Flags: online})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return db
+}
+
+// connect serves station over a pipe and returns a master session for it.
+func connect(t *testing.T, station *outstation.Outstation) *master.Session {
+	t.Helper()
+
+	return connectConfig(t, station, master.Config{LocalAddress: 1, RemoteAddress: 10})
+}
+
+// connectConfig is connect with a master configuration.
+func connectConfig(t *testing.T, station *outstation.Outstation, config master.Config) *master.Session {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	masterConn, outstationConn := net.Pipe()
+
+	go func() { _ = station.ServeConn(ctx, outstationConn) }()
+
+	session := master.NewSession(masterConn, config)
+
+	t.Cleanup(func() {
+		cancel()
+
+		_ = session.Close()
+	})
+
+	return session
+}
+
+func request(t *testing.T, session *master.Session, req *dnp3.ApplicationRequest) *dnp3.ApplicationResponse {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	responses, err := session.Request(ctx, req)
+	if err != nil {
+		t.Fatal("request:", err)
+	}
+
+	if len(responses) != 1 {
+		t.Fatalf("expected 1 response fragment, got %d", len(responses))
+	}
+
+	return responses[0]
+}
+
+func readRequest(headers ...dnp3.ObjectHeader) *dnp3.ApplicationRequest {
+	req := dnp3.NewApplicationRequest()
+	req.FunctionCode = dnp3.Read
+
+	for _, header := range headers {
+		req.Data.Objects = append(req.Data.Objects, dnp3.DataObject{Header: header})
+	}
+
+	return req
+}
+
+func rangeHeader(group, variation uint8, start, stop uint32) dnp3.ObjectHeader {
+	rangeField := dnp3.NewStartStopRangeField(start, stop)
+
+	return dnp3.ObjectHeader{
+		Group:         group,
+		Variation:     variation,
+		RangeSpecCode: rangeField.Code(),
+		RangeField:    rangeField,
+	}
+}
+
+// TestClass0 reads static data over TCP and clears the Restart IIN.
+func TestClass0(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var config net.ListenConfig
+
+	listener, err := config.Listen(ctx, "tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	station := outstation.New(outstation.Config{LocalAddress: 10, NeedTime: true}, newDatabase(t))
+
+	served := make(chan error, 1)
+
+	go func() { served <- station.Serve(ctx, listener) }()
+
+	session, err := master.Dial(ctx, "tcp", listener.Addr().String(),
+		master.Config{LocalAddress: 1, RemoteAddress: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer session.Close()
+
+	header, err := master.ClassHeader(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := request(t, session, readRequest(header))
+	iin := response.InternalIndications
+
+	if !iin.Restart || !iin.NeedTime || iin.ObjectUnknown || iin.ParameterError {
+		t.Fatalf("unexpected IIN: %+v", iin)
+	}
+
+	objects := response.Data.Objects
+	if len(objects) != 2 {
+		t.Fatalf("expected 2 objects (g1v2 0-1, g30v1 0-2 and 5), got %d", len(objects))
+	}
+
+	if objects[0].Header.Group != 1 || objects[0].Header.Variation != 2 ||
+		objects[0].Points[0].GetValue() != true || objects[0].Points[1].GetValue() != false {
+		t.Fatalf("unexpected binary inputs:\n%s", objects[0].String())
+	}
+
+	large, ok := objects[1].Points[2].(*dnp3.PointBytes)
+	if !ok {
+		t.Fatalf("expected PointBytes, got %T", objects[1].Points[2])
+	}
+
+	value, err := large.AsInt64()
+	if err != nil || value != 2147483647 {
+		t.Fatalf("expected clamped 2147483647, got %d (%v)", value, err)
+	}
+
+	flags, err := large.GetFlags()
+	if err != nil || !flags.OverRange || !flags.Online {
+		t.Fatalf("expected Online and OverRange flags, got %+v (%v)", flags, err)
+	}
+
+	if indexes := objects[1].Indexes(); !slices.Equal(indexes, []int{0, 1, 2, 5}) {
+		t.Fatalf("expected analog inputs 0-2 and 5, got %v", indexes)
+	}
+
+	points, err := dnp3.NewPoints(80, 1, dnp3.NoPrefix, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	write := dnp3.NewApplicationRequest()
+	write.FunctionCode = dnp3.Write
+	write.Data.Objects = []dnp3.DataObject{{Header: rangeHeader(80, 1, 7, 7), Points: points}}
+
+	iin = request(t, session, write).InternalIndications
+	if iin.Restart || iin.ParameterError || iin.ObjectUnknown {
+		t.Fatalf("expected Restart cleared, got %+v", iin)
+	}
+
+	cancel()
+
+	err = <-served
+	if err != nil {
+		t.Fatal("Serve:", err)
+	}
+}
+
+// TestClass0_fragments reads a database too large for one fragment, which
+// the outstation sends fragment by fragment as the master confirms them.
+func TestClass0_fragments(t *testing.T) {
+	t.Parallel()
+
+	const count = 1000
+
+	db := outstation.NewDatabase()
+	for index := range uint16(count) {
+		err := db.Set(outstation.AnalogInput, index, outstation.PointValue{Value: float64(index)})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	station := outstation.New(outstation.Config{LocalAddress: 10, ConfirmTimeout: 50 * time.Millisecond}, db)
+	session := connect(t, station)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	responses, err := session.IntegrityPoll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(responses) < 3 {
+		t.Fatalf("expected at least 3 fragments of %d points, got %d", count, len(responses))
+	}
+
+	var indexes []int
+
+	for i, response := range responses {
+		final := i == len(responses)-1
+		if response.Control.First != (i == 0) || response.Control.Final != final || response.Control.Confirm == final {
+			t.Errorf("fragment %d: unexpected control %+v", i, response.Control)
+		}
+
+		encoded, err := response.SerializeTo()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(encoded) > dnp3.DefaultMaxFragmentSize {
+			t.Errorf("fragment %d is %d bytes", i, len(encoded))
+		}
+
+		for _, object := range response.Data.Objects {
+			indexes = append(indexes, object.Indexes()...)
+		}
+	}
+
+	if len(indexes) != count || !slices.IsSorted(indexes) || indexes[count-1] != count-1 {
+		t.Fatalf("expected analog inputs 0-%d, got %d points", count-1, len(indexes))
+	}
+
+	// The confirm deadline is cleared once the last fragment is sent.
+	time.Sleep(100 * time.Millisecond)
+
+	response := request(t, session, readRequest(rangeHeader(30, 1, 0, 1)))
+	if len(response.Data.Objects) != 1 || len(response.Data.Objects[0].Points) != 2 {
+		t.Fatalf("expected analog inputs 0 and 1:\n%s", response.String())
+	}
+}
+
+// TestReadRange reads specific ranges and checks the IIN2 error bits.
+func TestReadRange(t *testing.T) {
+	t.Parallel()
+
+	session := connect(t, outstation.New(outstation.Config{LocalAddress: 10}, newDatabase(t)))
+
+	response := request(t, session, readRequest(rangeHeader(30, 5, 1, 2)))
+	if iin := response.InternalIndications; iin.ParameterError || iin.ObjectUnknown {
+		t.Fatalf("unexpected IIN: %+v", iin)
+	}
+
+	if len(response.Data.Objects) != 1 || len(response.Data.Objects[0].Points) != 2 {
+		t.Fatalf("expected one object with two points:\n%s", response.String())
+	}
+
+	point, ok := response.Data.Objects[0].Points[0].(*dnp3.PointBytes)
+	if !ok {
+		t.Fatalf("expected PointBytes, got %T", response.Data.Objects[0].Points[0])
+	}
+
+	value, err := point.AsFloat64()
+	if err != nil || value != -20 {
+		t.Fatalf("expected -20, got %v (%v)", value, err)
+	}
+
+	// Index 3 doesn't exist; 1, 2 and 5 are still returned.
+	response = request(t, session, readRequest(rangeHeader(30, 0, 1, 5)))
+	if !response.InternalIndications.ParameterError || len(response.Data.Objects) != 1 ||
+		!slices.Equal(response.Data.Objects[0].Indexes(), []int{1, 2, 5}) {
+		t.Fatalf("expected a parameter error and points 1, 2 and 5:\n%s", response.String())
+	}
+
+	// Without prefixes, a count of 4 selects indexes 0-3, and 3 doesn't exist.
+	count := dnp3.NewCountRangeField(4)
+
+	response = request(t, session, readRequest(dnp3.ObjectHeader{
+		Group:         30,
+		Variation:     5,
+		RangeSpecCode: count.Code(),
+		RangeField:    count,
+	}))
+	if !response.InternalIndications.ParameterError || len(response.Data.Objects) != 1 ||
+		len(response.Data.Objects[0].Points) != 3 {
+		t.Fatalf("expected a parameter error and three points:\n%s", response.String())
+	}
+
+	// No counters exist, so a range over every index is all missing.
+	response = request(t, session, readRequest(rangeHeader(20, 0, 0, math.MaxUint32)))
+	if !response.InternalIndications.ParameterError {
+		t.Fatalf("expected a parameter error:\n%s", response.String())
+	}
+
+	response = request(t, session, readRequest(rangeHeader(30, 7, 0, 0)))
+	if !response.InternalIndications.ObjectUnknown {
+		t.Fatal("expected ObjectUnknown for g30v7")
+	}
+
+	restart := dnp3.NewApplicationRequest()
+	restart.FunctionCode = dnp3.ColdRestart
+
+	if !request(t, session, restart).InternalIndications.BadFunction {
+		t.Fatal("expected BadFunction for cold restart")
+	}
+}
+
+// TestEvents assigns classes, buffers changes as events and reads them by
+// class and by event group.
+func TestEvents(t *testing.T) {
+	t.Parallel()
+
+	db := newDatabase(t)
+	station := outstation.New(outstation.Config{LocalAddress: 10, EventBufferSize: [3]int{0, 0, 1}}, db)
+	session := connect(t, station)
+
+	err := db.SetClass(outstation.AnalogInput, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	class1, err := master.ClassHeader(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assign := readRequest(class1, dnp3.ObjectHeader{
+		Group:         1,
+		RangeSpecCode: dnp3.NoRangeField,
+		RangeField:    &dnp3.AllRangeField{},
+	})
+	assign.FunctionCode = dnp3.AssignClass
+
+	if iin := request(t, session, assign).InternalIndications; iin.ObjectUnknown || iin.ParameterError {
+		t.Fatalf("unexpected IIN for assign class: %+v", iin)
+	}
+
+	if class, _ := db.Class(outstation.BinaryInput, 1); class != 1 {
+		t.Fatalf("expected binary input 1 in class 1, got %d", class)
+	}
+
+	for _, update := range []struct {
+		kind  outstation.PointType
+		value float64
+	}{
+		{outstation.AnalogInput, 5},
+		{outstation.AnalogInput, 6},
+		{outstation.BinaryInput, 0}, // unchanged, no event
+		{outstation.BinaryInput, 0},
+	} {
+		err = db.Set(update.kind, 0, outstation.PointValue{Value: update.value, Flags: dnp3.PointFlags{Online: true}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	class0, err := master.ClassHeader(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	iin := request(t, session, readRequest(class0)).InternalIndications
+	if !iin.Class1Events || !iin.Class2Events || iin.Class3Events {
+		t.Fatalf("expected class 1 and 2 events, got %+v", iin)
+	}
+
+	class2, err := master.ClassHeader(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := request(t, session, readRequest(class2))
+	if !response.Control.Confirm || response.InternalIndications.Class2Events {
+		t.Fatalf("expected a confirmable response with class 2 reported:\n%s", response.String())
+	}
+
+	if len(response.Data.Objects) != 1 || response.Data.Objects[0].Header.Group != 32 {
+		t.Fatalf("expected one g32 object:\n%s", response.String())
+	}
+
+	for i, want := range []int64{5, 6} {
+		point, ok := response.Data.Objects[0].Points[i].(*dnp3.PointBytes)
+		if !ok {
+			t.Fatalf("expected PointBytes, got %T", response.Data.Objects[0].Points[i])
+		}
+
+		value, err := point.AsInt64()
+		if err != nil || value != want {
+			t.Fatalf("event %d: expected %d, got %d (%v)", i, want, value, err)
+		}
+	}
+
+	// The session confirmed the response, so the events are gone.
+	if response = request(t, session, readRequest(class2)); len(response.Data.Objects) != 0 {
+		t.Fatalf("expected confirmed events to be released:\n%s", response.String())
+	}
+
+	response = request(t, session, readRequest(dnp3.ObjectHeader{
+		Group:         2,
+		Variation:     2,
+		RangeSpecCode: dnp3.NoRangeField,
+		RangeField:    &dnp3.AllRangeField{},
+	}))
+	if len(response.Data.Objects) != 1 || len(response.Data.Objects[0].Points) != 1 {
+		t.Fatalf("expected one binary input event:\n%s", response.String())
+	}
+
+	event := response.Data.Objects[0].Points[0]
+	index, err := event.GetIndex()
+	if err != nil || index != 0 {
+		t.Fatalf("expected index 0, got %d (%v)", index, err)
+	}
+
+	_, err = event.GetAbsTime()
+	if err != nil {
+		t.Fatal("expected a timestamp:", err)
+	}
+
+	// Class 3 holds a single event, so a second change overflows it.
+	err = db.SetClass(outstation.AnalogInput, 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, value := range []float64{1, 2} {
+		err = db.Set(outstation.AnalogInput, 1, outstation.PointValue{Value: value})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !request(t, session, readRequest(class0)).InternalIndications.BufferOverflow {
+		t.Fatal("expected BufferOverflow")
+	}
+}
+
+// controlObject returns a one-point control object for index with an
+// index-prefixed count qualifier (0x17).
+func controlObject(t *testing.T, group, variation uint8, index int, fill func(dnp3.Point) error) dnp3.DataObject {
+	t.Helper()
+
+	points, err := dnp3.NewPoints(group, variation, dnp3.Index1Octet, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = points[0].SetIndex(index)
+	if err == nil {
+		err = fill(points[0])
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rangeField := dnp3.NewCountRangeField(1)
+
+	return dnp3.DataObject{
+		Header: dnp3.ObjectHeader{
+			Group:           group,
+			Variation:       variation,
+			PointPrefixCode: dnp3.Index1Octet,
+			RangeSpecCode:   rangeField.Code(),
+			RangeField:      rangeField,
+		},
+		Points: points,
+	}
+}
+
+func latchOn(t *testing.T, index int) dnp3.DataObject {
+	t.Helper()
+
+	return controlObject(t, 12, 1, index, func(point dnp3.Point) error {
+		return point.SetValue(dnp3.CROB{OpType: dnp3.OpTypeLatchOn, Count: 1, OnTime: 100})
+	})
+}
+
+// TestControls runs select-before-operate and direct operate through a
+// master session against an outstation's command handlers.
+func TestControls(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+
+	handler := outstation.CommandHandler{
+		CROB: func(index uint16, crob dnp3.CROB, operate bool) dnp3.CommandStatus {
+			if index != 3 || crob.OpType != dnp3.OpTypeLatchOn {
+				return dnp3.CommandStatusNotSupported
+			}
+
+			calls = append(calls, fmt.Sprintf("crob %d %t", index, operate))
+
+			return dnp3.CommandStatusSuccess
+		},
+		AnalogOutput: func(index uint16, value float64, operate bool) dnp3.CommandStatus {
+			calls = append(calls, fmt.Sprintf("analog %d %v %t", index, value, operate))
+
+			return dnp3.CommandStatusSuccess
+		},
+	}
+
+	station := outstation.New(outstation.Config{LocalAddress: 10, Commands: handler}, newDatabase(t))
+	session := connect(t, station)
+	ctx := context.Background()
+
+	err := session.SelectAndOperate(ctx, latchOn(t, 3))
+	if err != nil {
+		t.Fatal("SelectAndOperate:", err)
+	}
+
+	analog, err := dnp3.NewAnalogOutputObject(3, map[int]float64{7: 1.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = session.DirectOperate(ctx, *analog)
+	if err != nil {
+		t.Fatal("DirectOperate:", err)
+	}
+
+	want := []string{"crob 3 false", "crob 3 true", "analog 7 1.5 true"}
+	if !slices.Equal(calls, want) {
+		t.Fatalf("expected handler calls %q, got %q", want, calls)
+	}
+
+	var commandErr *master.CommandError
+
+	err = session.DirectOperate(ctx, latchOn(t, 9))
+	if !errors.As(err, &commandErr) || commandErr.Index != 9 ||
+		commandErr.Status != dnp3.CommandStatusNotSupported {
+		t.Fatalf("expected NotSupported for index 9, got %v", err)
+	}
+
+	operate := dnp3.NewApplicationRequest()
+	operate.FunctionCode = dnp3.Operate
+	operate.Data.Objects = []dnp3.DataObject{latchOn(t, 3)}
+
+	response := request(t, session, operate)
+
+	crob, ok := response.Data.Objects[0].Points[0].(*dnp3.CROB)
+	if !ok || crob.Status != dnp3.CommandStatusNoSelect {
+		t.Fatalf("expected NoSelect for an operate without select:\n%s", response.String())
+	}
+
+	expired := outstation.New(outstation.Config{
+		LocalAddress:  10,
+		Commands:      handler,
+		SelectTimeout: time.Nanosecond,
+	}, newDatabase(t))
+
+	err = connect(t, expired).SelectAndOperate(ctx, latchOn(t, 3))
+	if !errors.As(err, &commandErr) || commandErr.Status != dnp3.CommandStatusTimeout {
+		t.Fatalf("expected Timeout, got %v", err)
+	}
+}
+
+// awaitUnsolicited returns the next unsolicited response passed to the
+// master callback.
+func awaitUnsolicited(t *testing.T, received <-chan *dnp3.ApplicationResponse) *dnp3.ApplicationResponse {
+	t.Helper()
+
+	select {
+	case response := <-received:
+		if response.FunctionCode != dnp3.UnsolicitedResponse || !response.Control.Unsolicited {
+			t.Fatalf("expected an unsolicited response:\n%s", response.String())
+		}
+
+		return response
+	case <-time.After(5 * time.Second):
+		t.Fatal("no unsolicited response")
+
+		return nil
+	}
+}
+
+// TestUnsolicited receives the null unsolicited response after the restart,
+// then the events of a class once the master enables it.
+func TestUnsolicited(t *testing.T) {
+	t.Parallel()
+
+	db := newDatabase(t)
+	station := outstation.New(outstation.Config{LocalAddress: 10, Unsolicited: true, MasterAddress: 1}, db)
+	received := make(chan *dnp3.ApplicationResponse, 8)
+	session := connectConfig(t, station, master.Config{
+		LocalAddress:  1,
+		RemoteAddress: 10,
+		Unsolicited:   func(response *dnp3.ApplicationResponse) { received <- response },
+	})
+	ctx := context.Background()
+
+	null := awaitUnsolicited(t, received)
+	if len(null.Data.Objects) != 0 || !null.InternalIndications.Restart {
+		t.Fatalf("expected a null response with Restart set:\n%s", null.String())
+	}
+
+	err := db.SetClass(outstation.AnalogInput, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.Set(outstation.AnalogInput, 0, outstation.PointValue{Value: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = session.EnableUnsolicited(ctx, 1)
+	if err != nil {
+		t.Fatal("EnableUnsolicited:", err)
+	}
+
+	response := awaitUnsolicited(t, received)
+	if response.Control.Sequence == null.Control.Sequence || len(response.Data.Objects) != 1 ||
+		response.Data.Objects[0].Header.Group != 32 {
+		t.Fatalf("expected a g32 event in a new unsolicited response:\n%s", response.String())
+	}
+
+	point, ok := response.Data.Objects[0].Points[0].(*dnp3.PointBytes)
+	if !ok {
+		t.Fatalf("expected PointBytes, got %T", response.Data.Objects[0].Points[0])
+	}
+
+	value, err := point.AsInt64()
+	if err != nil || value != 7 {
+		t.Fatalf("expected 7, got %d (%v)", value, err)
+	}
+
+	err = session.DisableUnsolicited(ctx, 1)
+	if err != nil {
+		t.Fatal("DisableUnsolicited:", err)
+	}
+
+	err = db.Set(outstation.AnalogInput, 0, outstation.PointValue{Value: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// With unsolicited reporting disabled, the new event waits for a poll.
+	class1, err := master.ClassHeader(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = request(t, session, readRequest(class1))
+	if len(response.Data.Objects) != 1 || len(response.Data.Objects[0].Points) != 1 {
+		t.Fatalf("expected only the new event to be polled:\n%s", response.String())
+	}
+
+	select {
+	case response = <-received:
+		t.Fatalf("unexpected unsolicited response:\n%s", response.String())
+	default:
+	}
+
+	err = connect(t, outstation.New(outstation.Config{LocalAddress: 10}, db)).EnableUnsolicited(ctx, 1)
+	if !errors.Is(err, master.ErrUnsolicitedRejected) {
+		t.Fatalf("expected ErrUnsolicitedRejected without unsolicited support, got %v", err)
+	}
+}
+
+// TestUnsolicited_retry leaves the null unsolicited response unconfirmed:
+// the outstation repeats it unchanged until the Confirm arrives.
+func TestUnsolicited_retry(t *testing.T) {
+	t.Parallel()
+
+	station := outstation.New(outstation.Config{
+		LocalAddress:              10,
+		Unsolicited:               true,
+		MasterAddress:             1,
+		UnsolicitedConfirmTimeout: 20 * time.Millisecond,
+	}, newDatabase(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	masterConn, outstationConn := net.Pipe()
+	defer masterConn.Close()
+
+	go func() { _ = station.ServeConn(ctx, outstationConn) }()
+
+	framer := dnp3.NewFramer(masterConn)
+	next := func() (*dnp3.Frame, error) {
+		err := masterConn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+		if err != nil {
+			return nil, err
+		}
+
+		return framer.Next()
+	}
+
+	var first *dnp3.ApplicationResponse
+
+	for attempt := range 3 {
+		frame, err := next()
+		if err != nil {
+			t.Fatalf("attempt %d: %v", attempt, err)
+		}
+
+		response, ok := frame.Application.(*dnp3.ApplicationResponse)
+		if !ok || frame.DataLink.Destination != 1 || !response.Control.Unsolicited {
+			t.Fatalf("attempt %d: expected an unsolicited response to the master:\n%s", attempt, frame.String())
+		}
+
+		if first == nil {
+			first = response
+		} else if response.Control.Sequence != first.Control.Sequence {
+			t.Fatalf("attempt %d: retry changed the sequence from %d to %d",
+				attempt, first.Control.Sequence, response.Control.Sequence)
+		}
+	}
+
+	confirm := dnp3.NewApplicationRequest()
+	confirm.FunctionCode = dnp3.Confirm
+	confirm.Control = dnp3.ApplicationControl{
+		First:       true,
+		Final:       true,
+		Unsolicited: true,
+		Sequence:    first.Control.Sequence,
+	}
+
+	var dataLink dnp3.DataLink
+	dataLink.Source = 1
+	dataLink.Destination = 10
+	dataLink.Control = dnp3.DataLinkControl{Direction: true, Primary: true, FunctionCode: dnp3.UnconfirmedUserData}
+
+	frames, err := dnp3.SegmentApplication(dataLink, confirm, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+
+	err = frames[0].SerializeTo(buf, gopacket.SerializeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = masterConn.Write(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Retries already in flight may still arrive; after that, silence.
+	for range 3 {
+		_, err = next()
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			return
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Fatal("still retrying after the Confirm")
+}
+
+// TestTimeSync sets the outstation clock from a master whose clock runs an
+// hour behind, with the delay measurement procedure, then two hours ahead
+// with the LAN procedure.
+func TestTimeSync(t *testing.T) {
+	t.Parallel()
+
+	var offset atomic.Int64
+
+	station := outstation.New(outstation.Config{LocalAddress: 10, NeedTime: true}, newDatabase(t))
+	session := connectConfig(t, station, master.Config{
+		LocalAddress:  1,
+		RemoteAddress: 10,
+		Clock:         func() time.Time { return time.Now().Add(time.Duration(offset.Load())) },
+	})
+	ctx := context.Background()
+
+	check := func(want time.Duration) {
+		t.Helper()
+
+		if skew := station.Now().Sub(time.Now().Add(want)); skew.Abs() > time.Second {
+			t.Fatalf("outstation clock is %s off", skew)
+		}
+	}
+
+	offset.Store(int64(-time.Hour))
+
+	delay, err := session.SyncTime(ctx)
+	if err != nil {
+		t.Fatal("SyncTime:", err)
+	}
+
+	if delay < 0 || delay > time.Second {
+		t.Fatalf("unexpected propagation delay %s", delay)
+	}
+
+	check(-time.Hour)
+
+	class0, err := master.ClassHeader(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if request(t, session, readRequest(class0)).InternalIndications.NeedTime {
+		t.Fatal("expected NeedTime cleared")
+	}
+
+	offset.Store(int64(2 * time.Hour))
+
+	err = session.SyncTimeLAN(ctx)
+	if err != nil {
+		t.Fatal("SyncTimeLAN:", err)
+	}
+
+	check(2 * time.Hour)
+}
@@ -0,0 +1,364 @@
+package outstation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/nblair2/go-dnp3/v2/dnp3"
+)
+
+// pointTypeInfo describes how a PointType is reported as static data and as
+// events.
+type pointTypeInfo struct {
+	group uint8
+	// defaultVariation answers class 0 reads and variation 0 requests.
+	defaultVariation uint8
+	variations       []uint8
+
+	eventGroup            uint8
+	defaultEventVariation uint8
+	eventVariations       []uint8
+}
+
+var pointTypes = [numPointTypes]pointTypeInfo{
+	BinaryInput: {
+		group: 1, defaultVariation: 2, variations: []uint8{1, 2},
+		eventGroup: 2, defaultEventVariation: 1, eventVariations: []uint8{1, 2},
+	},
+	DoubleBitBinaryInput: {
+		group: 3, defaultVariation: 2, variations: []uint8{1, 2},
+		eventGroup: 4, defaultEventVariation: 1, eventVariations: []uint8{1, 2},
+	},
+	BinaryOutputStatus: {
+		group: 10, defaultVariation: 2, variations: []uint8{1, 2},
+		eventGroup: 11, defaultEventVariation: 1, eventVariations: []uint8{1, 2},
+	},
+	Counter: {
+		group: 20, defaultVariation: 1, variations: []uint8{1, 2, 5, 6},
+		eventGroup: 22, defaultEventVariation: 1, eventVariations: []uint8{1, 2, 5, 6},
+	},
+	FrozenCounter: {
+		group: 21, defaultVariation: 1, variations: []uint8{1, 2, 9, 10},
+		eventGroup: 23, defaultEventVariation: 1, eventVariations: []uint8{1, 2, 5, 6},
+	},
+	AnalogInput: {
+		group: 30, defaultVariation: 1, variations: []uint8{1, 2, 3, 4, 5, 6},
+		eventGroup: 32, defaultEventVariation: 1, eventVariations: []uint8{1, 2, 3, 4, 5, 6, 7, 8},
+	},
+	AnalogOutputStatus: {
+		group: 40, defaultVariation: 1, variations: []uint8{1, 2, 3, 4},
+		eventGroup: 42, defaultEventVariation: 1, eventVariations: []uint8{1, 2, 3, 4, 5, 6, 7, 8},
+	},
+}
+
+// pointTypeOf returns the PointType reported in a static group.
+func pointTypeOf(group uint8) (PointType, bool) {
+	for kind, info := range pointTypes {
+		if info.group == group {
+			return PointType(kind), true //nolint:gosec // G115 - bounded by numPointTypes
+		}
+	}
+
+	return 0, false
+}
+
+// pointTypeOfEvent returns the PointType reported in an event group.
+func pointTypeOfEvent(group uint8) (PointType, bool) {
+	for kind, info := range pointTypes {
+		if info.eventGroup == group {
+			return PointType(kind), true //nolint:gosec // G115 - bounded by numPointTypes
+		}
+	}
+
+	return 0, false
+}
+
+// read appends the objects asked for by a Read request to response, flagging
+// headers it can't answer in the response IIN.
+func (o *Outstation) read(req *dnp3.ApplicationRequest, response *dnp3.ApplicationResponse) {
+	iin := &response.InternalIndications
+
+	for _, object := range req.Data.Objects {
+		var (
+			objects []dnp3.DataObject
+			err     error
+		)
+
+		if object.Header.Group == 60 {
+			objects, err = o.readClass(object)
+		} else if _, ok := pointTypeOfEvent(object.Header.Group); ok {
+			objects, err = o.readEvents(object)
+		} else {
+			objects, err = o.readStatic(object)
+		}
+
+		switch {
+		case errors.Is(err, errObjectUnknown):
+			iin.ObjectUnknown = true
+		case errors.Is(err, errParameter):
+			iin.ParameterError = true
+		case err != nil:
+			iin.DeviceTrouble = true
+		}
+
+		response.Data.Objects = append(response.Data.Objects, objects...)
+	}
+}
+
+// Errors that map onto IIN2 bits.
+var (
+	errObjectUnknown = errors.New("object unknown")
+	errParameter     = errors.New("parameter error")
+)
+
+// readClass returns the data of a Group 60 class read: every point for class
+// 0, and the buffered events of classes 1-3.
+func (o *Outstation) readClass(object dnp3.DataObject) ([]dnp3.DataObject, error) {
+	switch variation := object.Header.Variation; variation {
+	case 1:
+		var objects []dnp3.DataObject
+
+		for kind, info := range pointTypes {
+			kind := PointType(kind) //nolint:gosec // G115 - bounded by numPointTypes
+
+			static, err := o.staticObjects(kind, info.defaultVariation, o.database.Indexes(kind))
+			if err != nil {
+				return objects, err
+			}
+
+			objects = append(objects, static...)
+		}
+
+		return objects, nil
+	case 2, 3, 4:
+		class := variation - 1
+
+		return o.eventObjects(object, func(e *event) (uint8, bool) {
+			return pointTypes[e.kind].defaultEventVariation, e.class == class
+		}, false)
+	default:
+		return nil, fmt.Errorf("%w: group 60 variation %d", errObjectUnknown, variation)
+	}
+}
+
+// readStatic returns the points selected by a static group/variation header.
+// Points in the requested range that don't exist are skipped and reported as
+// a parameter error.
+func (o *Outstation) readStatic(object dnp3.DataObject) ([]dnp3.DataObject, error) {
+	header := object.Header
+
+	kind, ok := pointTypeOf(header.Group)
+	if !ok {
+		return nil, fmt.Errorf("%w: group %d", errObjectUnknown, header.Group)
+	}
+
+	variation := header.Variation
+	if variation == 0 {
+		variation = pointTypes[kind].defaultVariation
+	} else if !slices.Contains(pointTypes[kind].variations, variation) {
+		return nil, fmt.Errorf("%w: group %d variation %d", errObjectUnknown, header.Group, variation)
+	}
+
+	indexes, complete := selectIndexes(o.database.Indexes(kind), object)
+
+	objects, err := o.staticObjects(kind, variation, indexes)
+	if err == nil && !complete {
+		err = fmt.Errorf("%w: points outside the database requested", errParameter)
+	}
+
+	return objects, err
+}
+
+// selectIndexes returns the existing indexes, from all, selected by the
+// range of a Read header, and whether every selected point exists.
+func selectIndexes(all []uint16, object dnp3.DataObject) ([]uint16, bool) {
+	switch rangeField := object.Header.RangeField.(type) {
+	case *dnp3.AllRangeField:
+		return all, true
+	case *dnp3.StartStopRangeField:
+		if rangeField.Start > rangeField.Stop {
+			return nil, false
+		}
+
+		return selectRange(all, uint64(rangeField.Start), uint64(rangeField.Stop)-uint64(rangeField.Start)+1)
+	case *dnp3.CountRangeField:
+		// Without index prefixes, a count selects the points from index 0.
+		if object.Header.PointPrefixCode == dnp3.NoPrefix {
+			return selectRange(all, 0, uint64(rangeField.Count))
+		}
+
+		var selected []uint16
+
+		complete := true
+
+		for _, index := range object.Indexes() {
+			if index < 0 || index > math.MaxUint16 || !slices.Contains(all, uint16(index)) {
+				complete = false
+
+				continue
+			}
+
+			selected = append(selected, uint16(index))
+		}
+
+		slices.Sort(selected)
+
+		return slices.Compact(selected), complete
+	default:
+		return nil, false
+	}
+}
+
+// selectRange returns the indexes, from all, of the count points starting at
+// start, and whether every one of them exists. The bounds are 64-bit so that
+// a range covering every 32-bit index doesn't overflow.
+func selectRange(all []uint16, start, count uint64) ([]uint16, bool) {
+	var selected []uint16
+
+	for _, index := range all {
+		if uint64(index) >= start && uint64(index)-start < count {
+			selected = append(selected, index)
+		}
+	}
+
+	return selected, uint64(len(selected)) == count
+}
+
+// staticObjects encodes the given points of kind (ascending indexes, which
+// must exist) as group/variation objects. NewPointObjects picks their
+// qualifiers, and the points are then filled in from the database.
+func (o *Outstation) staticObjects(kind PointType, variation uint8, indexes []uint16) ([]dnp3.DataObject, error) {
+	values := o.database.values(kind, indexes)
+	byIndex := make(map[int]PointValue, len(indexes))
+	blank := make(map[int]any, len(indexes))
+
+	for i, index := range indexes {
+		byIndex[int(index)] = values[i]
+		blank[int(index)] = nil
+	}
+
+	objects, err := dnp3.NewPointObjects(pointTypes[kind].group, variation, blank)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, object := range objects {
+		for i, index := range object.Indexes() {
+			err = encodePoint(object.Points[i], kind, byIndex[index], time.Time{})
+			if err != nil {
+				return objects, fmt.Errorf("encoding %s %d: %w", kind, index, err)
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+// encodePoint fills a blank point with value, and with timestamp if the
+// variation carries a time. Numbers that the variation can't hold are clamped
+// and flagged OverRange.
+func encodePoint(point dnp3.Point, kind PointType, value PointValue, timestamp time.Time) error {
+	flags := value.Flags
+
+	switch point := point.(type) {
+	case *dnp3.PointBit:
+		err := point.SetValue(value.Value != 0)
+		if err != nil {
+			return err
+		}
+	case *dnp3.Point2Bits:
+		state := doubleBitState(value.Value)
+
+		return point.SetValue([2]bool{state&0b01 != 0, state&0b10 != 0})
+	case *dnp3.PointBytes:
+		var err error
+
+		// Binary and double-bit variations without a packed format keep
+		// their state in the top bits of the flags octet.
+		switch kind {
+		case BinaryInput, BinaryOutputStatus:
+			var state byte
+			if value.Value != 0 {
+				state = 1
+			}
+
+			err = point.SetValue([]byte{state<<7 | flags.ToByte()&0b01111111})
+		case DoubleBitBinaryInput:
+			err = point.SetValue([]byte{doubleBitState(value.Value)<<6 | flags.ToByte()&0b00111111})
+		case Counter, FrozenCounter, AnalogInput, AnalogOutputStatus, numPointTypes:
+			clamped, overRange := clampNumeric(point.NumericEncoding(), value.Value)
+			flags.OverRange = flags.OverRange || overRange
+
+			err = point.SetNumeric(clamped)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = point.SetAbsTime(dnp3.AbsoluteTime(timestamp))
+		if err != nil && !errors.Is(err, dnp3.ErrNoAbsTime) {
+			return err
+		}
+	default:
+		return fmt.Errorf("unexpected point type %T", point)
+	}
+
+	err := point.SetFlags(flags)
+	if err != nil && !errors.Is(err, dnp3.ErrNoFlags) {
+		return err
+	}
+
+	return nil
+}
+
+// doubleBitState converts a stored value to a 2-bit double-bit state.
+func doubleBitState(value float64) byte {
+	if value < 0 || value > DoubleBitIndeterminate || math.IsNaN(value) {
+		return DoubleBitIndeterminate
+	}
+
+	return byte(value)
+}
+
+// clampNumeric fits value into encoding, reporting whether it had to change.
+func clampNumeric(encoding dnp3.NumericEncoding, value float64) (float64, bool) {
+	var lower, upper float64
+
+	switch encoding {
+	case dnp3.NumericFloat64:
+		return value, false
+	case dnp3.NumericFloat32:
+		lower, upper = -math.MaxFloat32, math.MaxFloat32
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return value, false
+		}
+	case dnp3.NumericUint16:
+		lower, upper = 0, math.MaxUint16
+	case dnp3.NumericUint32:
+		lower, upper = 0, math.MaxUint32
+	case dnp3.NumericInt16:
+		lower, upper = math.MinInt16, math.MaxInt16
+	case dnp3.NumericInt32:
+		lower, upper = math.MinInt32, math.MaxInt32
+	case dnp3.NumericNone:
+		return value, false
+	default:
+		return value, false
+	}
+
+	if math.IsNaN(value) {
+		return 0, true
+	}
+
+	if !encoding.IsFloat() {
+		value = math.Round(value)
+	}
+
+	clamped := min(max(value, lower), upper)
+
+	return clamped, clamped != value
+}
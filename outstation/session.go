@@ -0,0 +1,266 @@
+package outstation
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/nblair2/go-dnp3/v2/dnp3"
+)
+
+// broadcastAddress is the lowest of the data link broadcast addresses.
+const broadcastAddress = 0xFFFD
+
+// DefaultConfirmTimeout is used when Config.ConfirmTimeout is zero.
+const DefaultConfirmTimeout = 5 * time.Second
+
+// session serves one master connection.
+type session struct {
+	outstation *Outstation
+	conn       net.Conn
+
+	writeMu      sync.Mutex // responses and unsolicited responses don't interleave
+	transportSeq uint8
+
+	// The fragments of a response still to send, each once the master
+	// confirms the one before (sent with sequence confirmSequence), to
+	// master. Only run touches them.
+	remaining       []*dnp3.ApplicationResponse
+	remainingMaster uint16
+	confirmSequence uint8
+}
+
+func newSession(outstation *Outstation, conn net.Conn) *session {
+	return &session{outstation: outstation, conn: conn}
+}
+
+// run reads requests from the connection and answers them until reading or
+// writing fails. With unsolicited responses enabled, they are sent from a
+// second goroutine that run stops, closing the connection, before returning.
+func (s *session) run() error {
+	if s.outstation.config.Unsolicited {
+		var wg sync.WaitGroup
+		defer wg.Wait()
+
+		defer s.conn.Close()
+
+		done := make(chan struct{})
+		defer close(done)
+
+		wg.Go(func() { s.reportUnsolicited(done) })
+	}
+
+	reassembler := dnp3.NewTransportReassembler()
+	buf := make([]byte, 4096)
+
+	var pending []byte
+
+	for {
+		n, err := s.conn.Read(buf)
+		if err != nil {
+			// The master never confirmed a fragment; drop the rest.
+			if len(s.remaining) > 0 && errors.Is(err, os.ErrDeadlineExceeded) {
+				err = s.dropRemaining()
+				if err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			return fmt.Errorf("reading request: %w", err)
+		}
+
+		frames, remainder, err := dnp3.ParseFrames(append(pending, buf[:n]...))
+		// A malformed frame poisons the rest of the buffer; drop it and start
+		// over with the next read.
+		pending = remainder
+		if err != nil {
+			pending = nil
+		}
+
+		for _, frame := range frames {
+			destination := frame.DataLink.Destination
+			if destination != s.outstation.config.LocalAddress && destination < broadcastAddress {
+				continue
+			}
+
+			app, err := reassembler.Push(frame)
+			if err != nil || app == nil {
+				continue
+			}
+
+			req, ok := app.(*dnp3.ApplicationRequest)
+			if !ok {
+				continue
+			}
+
+			next, err := s.continueResponse(req)
+			if err != nil {
+				return err
+			} else if next {
+				continue
+			}
+
+			fragments := s.outstation.handle(req, destination >= broadcastAddress)
+			if len(fragments) == 0 {
+				continue
+			}
+
+			err = s.respond(frame.DataLink.Source, fragments)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// respond sends the first fragment of a response, and keeps the others until
+// the master confirms it.
+func (s *session) respond(master uint16, fragments []*dnp3.ApplicationResponse) error {
+	err := s.send(master, fragments[0])
+	if err != nil {
+		return err
+	}
+
+	if len(fragments) == 1 {
+		return nil
+	}
+
+	s.remaining = fragments[1:]
+	s.remainingMaster = master
+	s.confirmSequence = fragments[0].Control.Sequence
+
+	return s.awaitConfirm()
+}
+
+// continueResponse sends the next fragment of a multi-fragment response if
+// req confirms the last one sent, and reports whether it did. Any other
+// request but an unsolicited Confirm drops the rest of the response.
+func (s *session) continueResponse(req *dnp3.ApplicationRequest) (bool, error) {
+	if len(s.remaining) == 0 || req.FunctionCode == dnp3.Confirm && req.Control.Unsolicited {
+		return false, nil
+	}
+
+	if req.FunctionCode != dnp3.Confirm || req.Control.Sequence != s.confirmSequence {
+		return false, s.dropRemaining()
+	}
+
+	next := s.remaining[0]
+	s.remaining = s.remaining[1:]
+	s.confirmSequence = next.Control.Sequence
+
+	err := s.send(s.remainingMaster, next)
+	if err != nil {
+		return true, err
+	}
+
+	if len(s.remaining) == 0 {
+		return true, s.clearDeadline()
+	}
+
+	return true, s.awaitConfirm()
+}
+
+// awaitConfirm bounds the wait for the Confirm of the fragment just sent.
+func (s *session) awaitConfirm() error {
+	timeout := s.outstation.config.ConfirmTimeout
+	if timeout <= 0 {
+		timeout = DefaultConfirmTimeout
+	}
+
+	err := s.conn.SetReadDeadline(time.Now().Add(timeout))
+	if err != nil {
+		return fmt.Errorf("setting confirm deadline: %w", err)
+	}
+
+	return nil
+}
+
+// dropRemaining abandons the rest of a multi-fragment response.
+func (s *session) dropRemaining() error {
+	s.remaining = nil
+
+	return s.clearDeadline()
+}
+
+func (s *session) clearDeadline() error {
+	err := s.conn.SetReadDeadline(time.Time{})
+	if err != nil {
+		return fmt.Errorf("clearing confirm deadline: %w", err)
+	}
+
+	return nil
+}
+
+// reportUnsolicited sends the unsolicited responses the outstation has due
+// until done is closed or writing fails.
+func (s *session) reportUnsolicited(done <-chan struct{}) {
+	outstation := s.outstation
+
+	for {
+		response, wait := outstation.nextUnsolicited(time.Now())
+		if response != nil {
+			err := s.send(outstation.config.MasterAddress, response)
+			if err != nil {
+				return
+			}
+		}
+
+		var retry <-chan time.Time
+		if wait > 0 {
+			retry = time.After(wait)
+		}
+
+		select {
+		case <-done:
+			return
+		case <-outstation.unsolicitedNotify:
+		case <-retry:
+		}
+	}
+}
+
+// send segments response into frames addressed to the master and writes them.
+func (s *session) send(master uint16, response *dnp3.ApplicationResponse) error {
+	var dataLink dnp3.DataLink
+	dataLink.Source = s.outstation.config.LocalAddress
+	dataLink.Destination = master
+	dataLink.Control.Primary = true
+	dataLink.Control.FunctionCode = dnp3.UnconfirmedUserData
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	frames, err := dnp3.SegmentApplication(dataLink, response, s.transportSeq)
+	if err != nil {
+		return fmt.Errorf("segmenting response: %w", err)
+	}
+
+	var wire []byte
+
+	for _, frame := range frames {
+		buf := gopacket.NewSerializeBuffer()
+
+		err = frame.SerializeTo(buf, gopacket.SerializeOptions{})
+		if err != nil {
+			return fmt.Errorf("encoding response frame: %w", err)
+		}
+
+		wire = append(wire, buf.Bytes()...)
+	}
+
+	//nolint:gosec // G115 - frame count is bounded by the fragment size
+	s.transportSeq = (s.transportSeq + uint8(len(frames))) & 0b00111111
+
+	_, err = s.conn.Write(wire)
+	if err != nil {
+		return fmt.Errorf("writing response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package outstation
+
+import (
+	"math"
+	"time"
+
+	"github.com/nblair2/go-dnp3/v2/dnp3"
+)
+
+// delayMeasurement answers a DelayMeasurement request with a Group 52 Var 2
+// object holding the time spent on the request since it was received.
+func (o *Outstation) delayMeasurement(response *dnp3.ApplicationResponse, received time.Time) {
+	points, err := dnp3.NewPoints(52, 2, dnp3.NoPrefix, 1)
+	if err == nil {
+		processing := min(time.Since(received).Round(time.Millisecond), math.MaxUint16*time.Millisecond)
+		err = points[0].SetRelTime(dnp3.RelativeTime(processing))
+	}
+
+	if err != nil {
+		response.InternalIndications.DeviceTrouble = true
+
+		return
+	}
+
+	rangeField := dnp3.NewCountRangeField(1)
+	response.Data.Objects = append(response.Data.Objects, dnp3.DataObject{
+		Header: dnp3.ObjectHeader{
+			Group:         52,
+			Variation:     2,
+			RangeSpecCode: rangeField.Code(),
+			RangeField:    rangeField,
+		},
+		Points: points,
+	})
+}
+
+// recordCurrentTime notes when a RecordCurrentTime request was received, for
+// the write of Group 50 Var 3 that follows it.
+func (o *Outstation) recordCurrentTime(received time.Time) {
+	o.mu.Lock()
+	o.recordedTime = received
+	o.mu.Unlock()
+}
+
+// setRecordedTime handles a write of Group 50 Var 3: the master's time when
+// it sent the last RecordCurrentTime request, which sets the outstation clock
+// as of when the request was received.
+func (o *Outstation) setRecordedTime(object dnp3.DataObject) bool {
+	if len(object.Points) != 1 {
+		return false
+	}
+
+	absTime, err := object.Points[0].GetAbsTime()
+	if err != nil {
+		return false
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.recordedTime.IsZero() {
+		return false
+	}
+
+	o.clockOffset = absTime.Time().Sub(o.recordedTime)
+	o.recordedTime = time.Time{}
+	o.needTime = false
+
+	return true
+}
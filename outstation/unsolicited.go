@@ -0,0 +1,152 @@
+package outstation
+
+import (
+	"slices"
+	"time"
+
+	"github.com/nblair2/go-dnp3/v2/dnp3"
+)
+
+// DefaultUnsolicitedConfirmTimeout is used when
+// Config.UnsolicitedConfirmTimeout is zero.
+const DefaultUnsolicitedConfirmTimeout = 5 * time.Second
+
+// unsolicitedResponse is the unsolicited response waiting for its Confirm.
+type unsolicitedResponse struct {
+	// response is nil while the response is being built.
+	response *dnp3.ApplicationResponse
+	// null marks the null response sent after the restart.
+	null   bool
+	sentAt time.Time
+}
+
+// setUnsolicited applies an EnableUnsolicited or DisableUnsolicited request
+// to the classes of its Group 60 Var 2-4 headers.
+func (o *Outstation) setUnsolicited(req *dnp3.ApplicationRequest, iin *dnp3.ApplicationInternalIndications) {
+	if !o.config.Unsolicited {
+		iin.BadFunction = true
+
+		return
+	}
+
+	enable := req.FunctionCode == dnp3.EnableUnsolicited
+
+	o.mu.Lock()
+
+	for _, object := range req.Data.Objects {
+		header := object.Header
+		if header.Group != 60 || header.Variation < 2 || header.Variation > 4 {
+			iin.ObjectUnknown = true
+
+			continue
+		}
+
+		o.unsolicitedEnabled[header.Variation-2] = enable
+	}
+
+	o.mu.Unlock()
+
+	o.notifyUnsolicited()
+}
+
+// notifyUnsolicited wakes a session to check for a due unsolicited response.
+func (o *Outstation) notifyUnsolicited() {
+	select {
+	case o.unsolicitedNotify <- struct{}{}:
+	default:
+	}
+}
+
+// nextUnsolicited returns the unsolicited response to send at now, if any,
+// and how long to wait before asking again; zero means until notified. The
+// null response goes first, and until it is confirmed no events are
+// reported. An unconfirmed response is repeated, unchanged, every confirm
+// timeout.
+func (o *Outstation) nextUnsolicited(now time.Time) (*dnp3.ApplicationResponse, time.Duration) {
+	timeout := o.config.UnsolicitedConfirmTimeout
+	if timeout <= 0 {
+		timeout = DefaultUnsolicitedConfirmTimeout
+	}
+
+	o.mu.Lock()
+
+	if pending := o.unsolicitedPending; pending != nil {
+		defer o.mu.Unlock()
+
+		if pending.response == nil {
+			return nil, timeout
+		}
+
+		if wait := pending.sentAt.Add(timeout).Sub(now); wait > 0 {
+			return nil, wait
+		}
+
+		pending.sentAt = now
+
+		return pending.response, timeout
+	}
+
+	null := o.unsolicitedNull
+	enabled := o.unsolicitedEnabled
+	reportable := slices.ContainsFunc(o.events.events, func(e *event) bool {
+		return !e.sent && enabled[e.class-1]
+	})
+
+	if !null && !reportable {
+		o.mu.Unlock()
+
+		return nil, 0
+	}
+
+	// Claim the slot so no other session builds a response meanwhile.
+	pending := &unsolicitedResponse{null: null}
+	o.unsolicitedPending = pending
+	o.mu.Unlock()
+
+	response := dnp3.NewApplicationResponse()
+	response.FunctionCode = dnp3.UnsolicitedResponse
+
+	var err error
+
+	if !null {
+		all := dnp3.DataObject{Header: dnp3.ObjectHeader{RangeField: &dnp3.AllRangeField{}}}
+		response.Data.Objects, err = o.eventObjects(all, func(e *event) (uint8, bool) {
+			return pointTypes[e.kind].defaultEventVariation, enabled[e.class-1]
+		}, true)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	response.Control = dnp3.ApplicationControl{
+		First:       true,
+		Final:       true,
+		Confirm:     true,
+		Unsolicited: true,
+		Sequence:    o.unsolicitedSequence,
+	}
+	o.unsolicitedSequence = (o.unsolicitedSequence + 1) & 0b00001111
+
+	o.indications(&response.InternalIndications)
+	response.InternalIndications.DeviceTrouble = err != nil
+
+	pending.response = response
+	pending.sentAt = now
+
+	return response, timeout
+}
+
+// confirmUnsolicited releases the unsolicited response a Confirm with
+// sequence is for. The caller holds o.mu.
+func (o *Outstation) confirmUnsolicited(sequence uint8) {
+	pending := o.unsolicitedPending
+	if pending == nil || pending.response == nil || pending.response.Control.Sequence != sequence {
+		return
+	}
+
+	o.events.release(true)
+	o.unsolicitedNull = o.unsolicitedNull && !pending.null
+	o.unsolicitedPending = nil
+
+	o.notifyUnsolicited()
+}